@@ -0,0 +1,227 @@
+package core
+
+import (
+	"encoding"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Binding tags, in order of lookup, e.g. `query:"page"` or `header:"X-Api-Key,required"`.
+// Options: "required", "unsigned" (cookie only, skip decryption), "utc" (time converted from c.Pub.TimeLoc to UTC).
+// Use `default:"1"` when the value is missing.
+var autoBindTags = []string{"query", "header", "cookie", "form"}
+
+// Time formats accepted by binding, parsed in c.Pub.TimeLoc
+var AutoBindTimeFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	durationType        = reflect.TypeOf(time.Duration(0))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// Bind field from request, returns false if field is not tagged.
+// Failures are added to httpErr as details.
+func autoBindField(c *Context, field reflect.Value, sf reflect.StructField, httpErr **HTTPError) bool {
+	source, tag := "", ""
+	for _, name := range autoBindTags {
+		if value, ok := sf.Tag.Lookup(name); ok {
+			source, tag = name, value
+			break
+		}
+	}
+	if source == "" {
+		return false
+	}
+
+	opts := strings.Split(tag, ",")
+	key := opts[0]
+	if key == "" {
+		key = sf.Name
+	}
+	option := func(name string) bool {
+		for _, opt := range opts[1:] {
+			if strings.TrimSpace(opt) == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	fail := func(msg string) {
+		if *httpErr == nil {
+			*httpErr = ErrBadRequest("")
+		}
+		*httpErr = (*httpErr).WithDetail(key, msg)
+	}
+
+	var values []string
+
+	switch source {
+	case "query":
+		values = c.Req.URL.Query()[key]
+	case "header":
+		values = c.Req.Header[http.CanonicalHeaderKey(key)]
+	case "cookie":
+		cookie := c.Cookie(key)
+		if option("unsigned") {
+			cookie = cookie.Unsigned()
+		}
+		if ck, err := cookie.Get(); err == nil {
+			values = []string{ck.Value}
+		}
+	case "form":
+		c.Req.ParseMultipartForm(c.App.FormMemoryLimit)
+		values = c.Req.PostForm[key]
+	}
+
+	if len(values) == 0 {
+		if def, ok := sf.Tag.Lookup("default"); ok {
+			values = []string{def}
+		} else if option("required") {
+			fail("required")
+			return true
+		} else {
+			return true
+		}
+	}
+
+	if err := autoBindValues(c, field, values); err != nil {
+		fail(err.Error())
+		return true
+	}
+	if option("utc") {
+		autoBindUTC(field)
+	}
+	return true
+}
+
+func autoBindUTC(field reflect.Value) {
+	switch {
+	case field.Type() == timeType:
+		field.Set(reflect.ValueOf(field.Interface().(time.Time).UTC()))
+	case field.Kind() == reflect.Ptr && !field.IsNil():
+		autoBindUTC(field.Elem())
+	case field.Kind() == reflect.Slice:
+		for i := 0; i < field.Len(); i++ {
+			autoBindUTC(field.Index(i))
+		}
+	}
+}
+
+func autoBindValues(c *Context, field reflect.Value, values []string) error {
+	t := field.Type()
+
+	if t.Kind() == reflect.Ptr && (t.Elem() == timeType || !t.Implements(textUnmarshalerType)) {
+		v := reflect.New(t.Elem())
+		if err := autoBindValues(c, v.Elem(), values); err != nil {
+			return err
+		}
+		field.Set(v)
+		return nil
+	}
+
+	if t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 && !reflect.PtrTo(t).Implements(textUnmarshalerType) {
+		s := reflect.MakeSlice(t, len(values), len(values))
+		for i, value := range values {
+			if err := autoBindValue(c, s.Index(i), value); err != nil {
+				return err
+			}
+		}
+		field.Set(s)
+		return nil
+	}
+
+	return autoBindValue(c, field, values[0])
+}
+
+func autoBindValue(c *Context, field reflect.Value, value string) error {
+	t := field.Type()
+
+	switch {
+	case t.Kind() == reflect.Ptr && t.Elem() == timeType:
+		// Same as time.Time, not the RFC 3339 only UnmarshalText
+		v := reflect.New(timeType)
+		if err := autoBindValue(c, v.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(v)
+		return nil
+	case t.Kind() == reflect.Ptr && t.Implements(textUnmarshalerType):
+		v := reflect.New(t.Elem())
+		if err := v.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value)); err != nil {
+			return err
+		}
+		field.Set(v)
+		return nil
+	case reflect.PtrTo(t).Implements(textUnmarshalerType) && t != timeType:
+		return field.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value))
+	case t == timeType:
+		for _, format := range AutoBindTimeFormats {
+			if tm, err := time.ParseInLocation(format, value, c.Pub.TimeLoc); err == nil {
+				field.Set(reflect.ValueOf(tm))
+				return nil
+			}
+		}
+		return fmt.Errorf("invalid time")
+	case t == durationType:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid duration")
+		}
+		field.SetInt(int64(d))
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		switch strings.ToLower(value) {
+		case "on", "yes":
+			field.SetBool(true)
+		case "off", "no":
+			field.SetBool(false)
+		default:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid boolean")
+			}
+			field.SetBool(b)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(value, 10, t.Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer")
+		}
+		field.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(value, 10, t.Bits())
+		if err != nil {
+			return fmt.Errorf("invalid unsigned integer")
+		}
+		field.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, t.Bits())
+		if err != nil {
+			return fmt.Errorf("invalid number")
+		}
+		field.SetFloat(f)
+	case reflect.Slice:
+		// []byte
+		field.SetBytes([]byte(value))
+	default:
+		return fmt.Errorf("unsupported type %v", t)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+type autoBindLevel int
+
+func (l *autoBindLevel) UnmarshalText(b []byte) error {
+	switch string(b) {
+	case "low":
+		*l = 1
+	case "high":
+		*l = 2
+	default:
+		return ErrorStr("invalid level")
+	}
+	return nil
+}
+
+type autoBindDummy struct {
+	Page    int           `query:"page" default:"1"`
+	Tags    []string      `query:"tag"`
+	Debug   bool          `query:"debug"`
+	Since   time.Time     `query:"since"`
+	Timeout time.Duration `query:"timeout"`
+	Limit   *int          `query:"limit"`
+	Level   autoBindLevel `query:"level"`
+	ApiKey  string        `header:"X-Api-Key,required"`
+	Pref    string        `cookie:"pref,unsigned"`
+}
+
+func TestAutoBind(t *testing.T) {
+	App := NewApp()
+
+	App.Debug = true
+
+	App.TestView = RouteHandlerFunc(func(c *Context) {
+		c.Pub.Errors.Register(400, func(c *Context, err error) {
+			c.Pub.Group.Set("result", "400")
+		})
+
+		q := url.Values{
+			"tag":     {"a", "b"},
+			"debug":   {"on"},
+			"since":   {"2020-01-02"},
+			"timeout": {"1m30s"},
+			"level":   {"high"},
+		}
+		c.Req.URL.RawQuery = q.Encode()
+		c.Req.Header.Set("X-Api-Key", "key")
+		c.Req.AddCookie(&http.Cookie{Name: "pref", Value: "dark"})
+
+		d := &autoBindDummy{}
+		c.Auto().PopulateStructFields(d)
+
+		if c.Terminated() || d.Page != 1 || len(d.Tags) != 2 || d.Tags[1] != "b" || !d.Debug ||
+			d.Since.Day() != 2 || d.Since.Location() != c.Pub.TimeLoc || d.Timeout != 90*time.Second ||
+			d.Limit != nil || d.Level != 2 || d.ApiKey != "key" || d.Pref != "dark" {
+			t.Fail()
+		}
+
+		q.Set("limit", "10")
+		c.Req.URL.RawQuery = q.Encode()
+
+		d = &autoBindDummy{}
+		c.Auto().PopulateStructFields(d)
+
+		if d.Limit == nil || *d.Limit != 10 {
+			t.Fail()
+		}
+
+		q.Set("page", "abc")
+		c.Req.URL.RawQuery = q.Encode()
+		c.Req.Header.Del("X-Api-Key")
+
+		d = &autoBindDummy{}
+		c.Auto().PopulateStructFields(d)
+
+		httpErr, _ := c.ErrorCause().(*HTTPError)
+
+		if c.Pub.Group.Get("result") != "400" || c.Pub.Status != 400 || httpErr == nil ||
+			httpErr.Details["page"] != "invalid integer" || httpErr.Details["X-Api-Key"] != "required" {
+			t.Fail()
+		}
+	})
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	http.Get(ts.URL)
+}
+
+func TestAutoBindTimePointer(t *testing.T) {
+	type Dummy struct {
+		Begin *time.Time   `query:"b"`
+		Dates []time.Time  `query:"d"`
+		Ptrs  []*time.Time `query:"p"`
+		End   *time.Time   `query:"e,utc"`
+	}
+
+	App := NewApp()
+	App.Debug = true
+	App.SetTimeZone("Europe/Paris")
+
+	App.TestView = RouteHandlerFunc(func(c *Context) {
+		c.Req.URL.RawQuery = "b=2024-01-02&d=2024-01-03&d=2024-01-04T10:00&p=2024-01-05&e=2024-07-01T10:30"
+
+		d := &Dummy{}
+		c.Auto().PopulateStructFields(d)
+
+		end := time.Date(2024, 7, 1, 8, 30, 0, 0, time.UTC)
+		if c.Terminated() || d.Begin == nil || d.Begin.Day() != 2 || d.Begin.Location() != c.Pub.TimeLoc ||
+			len(d.Dates) != 2 || d.Dates[1].Hour() != 10 || d.Dates[1].Location() != c.Pub.TimeLoc ||
+			len(d.Ptrs) != 1 || d.Ptrs[0].Day() != 5 ||
+			d.End == nil || !d.End.Equal(end) || d.End.Location() != time.UTC {
+			t.Errorf("%+v", d)
+		}
+		c.Fmt().Print("ok")
+	})
+
+	res := httptest.NewRecorder()
+	App.ServeHTTP(res, httptest.NewRequest("GET", "/", nil))
+
+	if res.Code != 200 || res.Body.String() != "ok" {
+		t.Error(res.Code, res.Body.String())
+	}
+}
@@ -0,0 +1,367 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type Cache struct {
+	c *Context
+}
+
+// HTTP Caching, Cache-Control directives and conditional requests.
+func (c *Context) Cache() Cache {
+	return Cache{c}
+}
+
+func (ca Cache) directives() []string {
+	directives := []string{}
+	for _, directive := range strings.Split(ca.c.Res.Header().Get("Cache-Control"), ",") {
+		if directive = strings.TrimSpace(directive); directive != "" {
+			directives = append(directives, directive)
+		}
+	}
+	return directives
+}
+
+// Set directive, replacing directive of the same name and removing conflicting ones.
+func (ca Cache) directive(directive string, conflicts ...string) Cache {
+	name := strings.SplitN(directive, "=", 2)[0]
+	out := []string{}
+	for _, existing := range ca.directives() {
+		existingName := strings.SplitN(existing, "=", 2)[0]
+		if existingName == name {
+			continue
+		}
+		conflict := false
+		for _, c := range conflicts {
+			conflict = conflict || existingName == c
+		}
+		if !conflict {
+			out = append(out, existing)
+		}
+	}
+	out = append(out, directive)
+	ca.c.Res.Header().Set("Cache-Control", strings.Join(out, ", "))
+	return ca
+}
+
+func cacheSeconds(d time.Duration) string {
+	return fmt.Sprint(int64(d / time.Second))
+}
+
+// Set Cache-Control header as is
+func (ca Cache) Control(directives ...string) Cache {
+	ca.c.Res.Header().Set("Cache-Control", strings.Join(directives, ", "))
+	return ca
+}
+
+// Cacheable by browsers and shared caches
+func (ca Cache) Public(maxAge time.Duration) Cache {
+	return ca.directive("public", "private", "no-store").MaxAge(maxAge)
+}
+
+// Cacheable by browsers only
+func (ca Cache) Private(maxAge time.Duration) Cache {
+	return ca.directive("private", "public", "no-store").MaxAge(maxAge)
+}
+
+// Set max-age
+func (ca Cache) MaxAge(maxAge time.Duration) Cache {
+	return ca.directive("max-age=" + cacheSeconds(maxAge))
+}
+
+// Set s-maxage, for shared caches
+func (ca Cache) SMaxAge(maxAge time.Duration) Cache {
+	return ca.directive("s-maxage=" + cacheSeconds(maxAge))
+}
+
+// Must revalidate before use
+func (ca Cache) NoCache() Cache {
+	return ca.directive("no-cache", "no-store")
+}
+
+// Do not store at all
+func (ca Cache) NoStore() Cache {
+	ca.c.Res.Header().Set("Cache-Control", "no-store")
+	return ca
+}
+
+// Never changes during max-age, e.g. fingerprinted assets
+func (ca Cache) Immutable() Cache {
+	return ca.directive("immutable")
+}
+
+// Stale response must not be used without revalidation
+func (ca Cache) MustRevalidate() Cache {
+	return ca.directive("must-revalidate")
+}
+
+// Stale response may be used while revalidating in the background
+func (ca Cache) StaleWhileRevalidate(d time.Duration) Cache {
+	return ca.directive("stale-while-revalidate=" + cacheSeconds(d))
+}
+
+// Add request header to Vary
+func (ca Cache) Vary(header string) Cache {
+	header = http.CanonicalHeaderKey(header)
+	if !headerHasToken(ca.c.Res.Header(), "Vary", header) {
+		ca.c.Res.Header().Add("Vary", header)
+	}
+	return ca
+}
+
+// Strong ETag from content, see WeakETag
+func ETag(content []byte) string {
+	sum := sha1.Sum(content)
+	return `"` + hex.EncodeToString(sum[:12]) + `"`
+}
+
+// Weak ETag from content, for content equivalent but not byte identical (e.g. compressed).
+func WeakETag(content []byte) string {
+	return "W/" + ETag(content)
+}
+
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, `"`) || strings.HasPrefix(etag, `W/"`) {
+		return etag
+	}
+	return `"` + etag + `"`
+}
+
+// Match entity tag list (If-Match, If-None-Match), strong or weak comparison.
+func etagMatch(list, etag string, weak bool) bool {
+	if etag == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if weak {
+			if strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+			continue
+		}
+		if !strings.HasPrefix(candidate, "W/") && !strings.HasPrefix(etag, "W/") && candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+func safeMethod(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS":
+		return true
+	}
+	return false
+}
+
+// Set ETag and evaluate preconditions, returns true if answered (304 or 412).
+func (ca Cache) ETag(etag string) bool {
+	ca.c.Res.Header().Set("ETag", quoteETag(etag))
+	return ca.evaluate()
+}
+
+// Set Last-Modified and evaluate preconditions, returns true if answered (304 or 412).
+func (ca Cache) LastModified(modTime time.Time) bool {
+	if !modTime.IsZero() {
+		ca.c.Res.Header().Set("Last-Modified", modTime.UTC().Format(http.TimeFormat))
+	}
+	return ca.evaluate()
+}
+
+// Set ETag and Last-Modified (zero values are skipped), then evaluate preconditions.
+// Returns true if answered with 304 Not Modified or 412 Precondition Failed.
+func (ca Cache) Validate(etag string, modTime time.Time) bool {
+	if etag != "" {
+		ca.c.Res.Header().Set("ETag", quoteETag(etag))
+	}
+	return ca.LastModified(modTime)
+}
+
+func (ca Cache) evaluate() bool {
+	c := ca.c
+	header := c.Res.Header()
+	etag := header.Get("ETag")
+	modTime, modErr := http.ParseTime(header.Get("Last-Modified"))
+
+	if !safeMethod(c.Req.Method) {
+		if im := c.Req.Header.Get("If-Match"); im != "" {
+			if etag != "" && !etagMatch(im, etag, false) {
+				return ca.preconditionFailed()
+			}
+		} else if ius := c.Req.Header.Get("If-Unmodified-Since"); ius != "" && modErr == nil {
+			if t, err := http.ParseTime(ius); err == nil && modTime.After(t) {
+				return ca.preconditionFailed()
+			}
+		}
+	}
+
+	if inm := c.Req.Header.Get("If-None-Match"); inm != "" {
+		if !etagMatch(inm, etag, true) {
+			return false
+		}
+		if c.Req.Method == "GET" || c.Req.Method == "HEAD" {
+			return ca.notModified()
+		}
+		return ca.preconditionFailed()
+	}
+
+	if c.Req.Method != "GET" && c.Req.Method != "HEAD" {
+		return false
+	}
+
+	if ims := c.Req.Header.Get("If-Modified-Since"); ims != "" && modErr == nil {
+		if t, err := http.ParseTime(ims); err == nil && !modTime.After(t) {
+			return ca.notModified()
+		}
+	}
+	return false
+}
+
+func (ca Cache) notModified() bool {
+	header := ca.c.Res.Header()
+	header.Del("Content-Type")
+	header.Del("Content-Length")
+	header.Del("Content-Encoding")
+	ca.c.Res.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+func (ca Cache) preconditionFailed() bool {
+	ca.c.Error(http.StatusPreconditionFailed, nil)
+	return true
+}
+
+// Buffers response until the handler returns, or passes through once flushed or hijacked.
+type responseBuffer struct {
+	rw        http.ResponseWriter
+	status    int
+	buf       bytes.Buffer
+	streaming bool
+}
+
+func (rb *responseBuffer) Header() http.Header {
+	return rb.rw.Header()
+}
+
+func (rb *responseBuffer) WriteHeader(status int) {
+	if rb.streaming {
+		rb.rw.WriteHeader(status)
+		return
+	}
+	if rb.status == 0 {
+		rb.status = status
+	}
+}
+
+func (rb *responseBuffer) Write(p []byte) (int, error) {
+	if rb.streaming {
+		return rb.rw.Write(p)
+	}
+	if rb.status == 0 {
+		rb.status = http.StatusOK
+	}
+	return rb.buf.Write(p)
+}
+
+func (rb *responseBuffer) stream() {
+	if rb.streaming {
+		return
+	}
+	rb.streaming = true
+	if rb.status != 0 {
+		rb.rw.WriteHeader(rb.status)
+	}
+	if rb.buf.Len() > 0 {
+		rb.rw.Write(rb.buf.Bytes())
+		rb.buf.Reset()
+	}
+}
+
+func (rb *responseBuffer) Flush() {
+	rb.stream()
+	if fl, ok := rb.rw.(http.Flusher); ok {
+		fl.Flush()
+	}
+}
+
+func (rb *responseBuffer) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	rb.streaming = true
+	hj, ok := rb.rw.(http.Hijacker)
+	if !ok {
+		return nil, nil, ErrorStr("Connection is not Hijackable")
+	}
+	return hj.Hijack()
+}
+
+// Buffer response of next, body is included for HEAD requests as well.
+// Returns nil if the response was streamed.
+func bufferResponse(c *Context, next func()) *responseBuffer {
+	rb := &responseBuffer{rw: resWriter{c.Res, c.pri.reswrite}}
+	restore := c.swapResponseWriter(rb, c.Req)
+	c.pri.reswrite = rb
+	defer func() {
+		if r := recover(); r != nil {
+			restore()
+			if !rb.streaming {
+				// Buffered output is discarded, the panic response goes out as first write
+				c.pri.firstWrite, c.pri.cut = true, false
+			}
+			panic(r)
+		}
+	}()
+	next()
+	// Pipelines written by next are complete before the output is hashed or stored
+	c.closePipelines()
+	restore()
+	if rb.streaming {
+		return nil
+	}
+	return rb
+}
+
+// Write buffered response to client
+func (rb *responseBuffer) flushTo(c *Context) {
+	if rb.status == 0 {
+		return
+	}
+	c.Res.WriteHeader(rb.status)
+	c.Res.Write(rb.buf.Bytes())
+}
+
+// Middleware, computes weak ETag of buffered GET and HEAD 200 responses and answers 304.
+// Responses that already have an ETag are evaluated as is, streamed responses are skipped.
+func AutoETag(c *Context, next func()) {
+	if c.Req.Method != "GET" && c.Req.Method != "HEAD" {
+		next()
+		return
+	}
+
+	rb := bufferResponse(c, next)
+	if rb == nil {
+		return
+	}
+
+	if rb.status == http.StatusOK {
+		if rb.Header().Get("ETag") == "" {
+			rb.Header().Set("ETag", WeakETag(rb.buf.Bytes()))
+		}
+		if c.Cache().evaluate() {
+			return
+		}
+	}
+
+	rb.flushTo(c)
+}
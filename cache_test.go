@@ -0,0 +1,132 @@
+package core
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCacheControl(t *testing.T) {
+	App := NewApp()
+
+	App.Debug = true
+
+	App.TestView = RouteHandlerFunc(func(c *Context) {
+		c.Cache().Private(time.Minute).Public(time.Hour).MustRevalidate()
+
+		if c.Res.Header().Get("Cache-Control") != "public, max-age=3600, must-revalidate" {
+			t.Fail()
+		}
+
+		c.Cache().NoStore()
+
+		if c.Res.Header().Get("Cache-Control") != "no-store" {
+			t.Fail()
+		}
+
+		c.Cache().Vary("accept-language").Vary("Accept-Language")
+
+		if len(c.Res.Header()["Vary"]) != 1 {
+			t.Fail()
+		}
+	})
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	http.Get(ts.URL)
+}
+
+func TestCacheConditional(t *testing.T) {
+	App := NewApp()
+
+	modTime := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	App.DefaultRouter = NewDirRouter().RootDir(RouteHandlerFunc(func(c *Context) {
+		if c.Cache().Validate("v1", modTime) {
+			return
+		}
+		c.Fmt().Print("content")
+	})).Register("auto", WithMiddleware(RouteHandlerFunc(func(c *Context) {
+		c.Fmt().Print("auto content")
+	}), AutoETag))
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	do := func(method, path string, header map[string]string) (*http.Response, string) {
+		req, _ := http.NewRequest(method, ts.URL+path, nil)
+		for key, value := range header {
+			req.Header.Set(key, value)
+		}
+		res, err := http.DefaultClient.Do(req)
+		Check(err)
+		b, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		return res, string(b)
+	}
+
+	if res, body := do("GET", "/", nil); res.StatusCode != 200 || body != "content" || res.Header.Get("ETag") != `"v1"` {
+		t.Fail()
+	}
+
+	if res, body := do("GET", "/", map[string]string{"If-None-Match": `W/"v1"`}); res.StatusCode != 304 || body != "" {
+		t.Fail()
+	}
+
+	if res, _ := do("GET", "/", map[string]string{"If-Modified-Since": modTime.Format(http.TimeFormat)}); res.StatusCode != 304 {
+		t.Fail()
+	}
+
+	if res, _ := do("GET", "/", map[string]string{"If-Modified-Since": modTime.Add(-time.Hour).Format(http.TimeFormat)}); res.StatusCode != 200 {
+		t.Fail()
+	}
+
+	if res, _ := do("PUT", "/", map[string]string{"If-Match": `"v0"`}); res.StatusCode != 412 {
+		t.Fail()
+	}
+
+	if res, _ := do("PUT", "/", map[string]string{"If-Match": `"v1"`}); res.StatusCode != 200 {
+		t.Fail()
+	}
+
+	res, body := do("GET", "/auto", nil)
+	etag := res.Header.Get("ETag")
+
+	if res.StatusCode != 200 || body != "auto content" || etag != WeakETag([]byte("auto content")) {
+		t.Fail()
+	}
+
+	if res, body := do("GET", "/auto", map[string]string{"If-None-Match": etag}); res.StatusCode != 304 || body != "" {
+		t.Fail()
+	}
+
+	if res, _ := do("HEAD", "/auto", nil); res.Header.Get("ETag") != etag {
+		t.Fail()
+	}
+}
+
+func TestAutoETagPanic(t *testing.T) {
+	App := NewApp()
+
+	panicHandler := DefaultPanicHandler
+	defer func() {
+		DefaultPanicHandler = panicHandler
+	}()
+	DefaultPanicHandler = panicFunc(func(c *Context, r interface{}, stack []byte) {})
+
+	App.DefaultRouter = NewDirRouter().Register("panic", WithMiddleware(RouteHandlerFunc(func(c *Context) {
+		c.Fmt().Print("partial")
+		panic("crash")
+	}), AutoETag))
+
+	res := httptest.NewRecorder()
+	App.ServeHTTP(res, httptest.NewRequest("GET", "/panic", nil))
+
+	if res.Code != 500 || res.Body.Len() == 0 || strings.Contains(res.Body.String(), "partial") {
+		t.Errorf("%d %q", res.Code, res.Body.String())
+	}
+}
@@ -0,0 +1,232 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+)
+
+// Service Scope
+type Scope int
+
+const (
+	// One instance per App
+	Singleton Scope = iota
+	// One instance per Request, disposed at the end of the Request
+	PerRequest
+	// New instance every time it is resolved, disposed at the end of the Request
+	Transient
+)
+
+// Implemented by services that need cleaning up, io.Closer is also accepted.
+type Disposer interface {
+	Dispose()
+}
+
+var (
+	contextType = reflect.TypeOf((*Context)(nil))
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+type provider struct {
+	scope Scope
+	fn    reflect.Value
+	typ   reflect.Type
+	ctx   bool
+
+	mu    sync.Mutex
+	value reflect.Value
+	ok    bool
+}
+
+func newProvider(scope Scope, fn interface{}) *provider {
+	v := reflect.ValueOf(fn)
+	t := v.Type()
+	if t.Kind() != reflect.Func || t.IsVariadic() || t.NumIn() > 1 || t.NumOut() < 1 || t.NumOut() > 2 ||
+		(t.NumIn() == 1 && t.In(0) != contextType) || (t.NumOut() == 2 && t.Out(1) != errorType) {
+		panic(fmt.Errorf("%v must be func(*Context) T or func(*Context) (T, error), *Context is optional", t))
+	}
+	return &provider{scope: scope, fn: v, typ: t.Out(0), ctx: t.NumIn() == 1}
+}
+
+func (p *provider) call(c *Context) (reflect.Value, error) {
+	in := []reflect.Value{}
+	if p.ctx {
+		in = append(in, reflect.ValueOf(c))
+	}
+	out := p.fn.Call(in)
+	if len(out) == 2 && !out[1].IsNil() {
+		return reflect.Value{}, out[1].Interface().(error)
+	}
+	return out[0], nil
+}
+
+func (p *provider) singleton(c *Context) (reflect.Value, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.ok {
+		return p.value, nil
+	}
+	value, err := p.call(c)
+	if err != nil {
+		return value, err
+	}
+	p.value, p.ok = value, true
+	return value, nil
+}
+
+// Service Container, providers are registered by type or by name.
+type Container struct {
+	sync.RWMutex
+	types map[reflect.Type]*provider
+	names map[string]*provider
+}
+
+// Construct New Container
+func NewContainer() *Container {
+	return &Container{
+		types: map[reflect.Type]*provider{},
+		names: map[string]*provider{},
+	}
+}
+
+// Register Provider by return type,
+// fn must be func(*Context) T or func(*Context) (T, error), *Context is optional.
+func (co *Container) Provide(scope Scope, fn interface{}) *Container {
+	p := newProvider(scope, fn)
+	co.Lock()
+	defer co.Unlock()
+	co.types[p.typ] = p
+	return co
+}
+
+// Register Provider by name, see Provide
+func (co *Container) ProvideNamed(name string, scope Scope, fn interface{}) *Container {
+	p := newProvider(scope, fn)
+	co.Lock()
+	defer co.Unlock()
+	co.names[name] = p
+	return co
+}
+
+// Register existing value as Singleton by type
+func (co *Container) Instance(value interface{}) *Container {
+	v := reflect.ValueOf(value)
+	p := &provider{scope: Singleton, typ: v.Type(), value: v, ok: true}
+	co.Lock()
+	defer co.Unlock()
+	co.types[p.typ] = p
+	return co
+}
+
+func (co *Container) lookup(name string, t reflect.Type) *provider {
+	co.RLock()
+	defer co.RUnlock()
+	if name != "" {
+		return co.names[name]
+	}
+	return co.types[t]
+}
+
+// Per-request service state
+type services struct {
+	values  map[*provider]reflect.Value
+	dispose []reflect.Value
+}
+
+func (co *Container) resolve(c *Context, name string, t reflect.Type) (reflect.Value, error) {
+	p := co.lookup(name, t)
+	if p == nil {
+		if name != "" {
+			return reflect.Value{}, fmt.Errorf("core: no service named '%s'", name)
+		}
+		return reflect.Value{}, fmt.Errorf("core: no service of type %v", t)
+	}
+	if !p.typ.AssignableTo(t) {
+		return reflect.Value{}, fmt.Errorf("core: service %v is not assignable to %v", p.typ, t)
+	}
+
+	switch p.scope {
+	case Singleton:
+		return p.singleton(c)
+	case PerRequest:
+		if value, ok := c.pri.services.values[p]; ok {
+			return value, nil
+		}
+	}
+
+	value, err := p.call(c)
+	if err != nil {
+		return value, err
+	}
+
+	if p.scope == PerRequest {
+		if c.pri.services.values == nil {
+			c.pri.services.values = map[*provider]reflect.Value{}
+		}
+		c.pri.services.values[p] = value
+	}
+	c.pri.services.dispose = append(c.pri.services.dispose, value)
+
+	return value, nil
+}
+
+// Inject services into struct fields tagged `inject:""` (by type) or `inject:"name"` (by name).
+// Errors are handled by c.HandleError.
+func (co *Container) inject(c *Context, vc reflect.Value) {
+	s := vc.Elem()
+	typeOfT := s.Type()
+
+	for i := 0; i < s.NumField(); i++ {
+		name, ok := typeOfT.Field(i).Tag.Lookup("inject")
+		field := s.Field(i)
+		if !ok || !field.CanSet() {
+			continue
+		}
+		value, err := co.resolve(c, name, field.Type())
+		if err != nil {
+			c.HandleError(err)
+			return
+		}
+		field.Set(value)
+	}
+}
+
+// Dispose per-request and transient services in reverse order.
+func (c *Context) disposeServices() {
+	dispose := c.pri.services.dispose
+	c.pri.services = services{}
+	for i := len(dispose) - 1; i >= 0; i-- {
+		switch t := dispose[i].Interface().(type) {
+		case Disposer:
+			t.Dispose()
+		case io.Closer:
+			t.Close()
+		}
+	}
+}
+
+// Get Service Container
+func (app *App) Services() *Container {
+	return app.services
+}
+
+// Resolve service into pointer by type, e.g. var db *sql.DB; c.Service(&db)
+func (c *Context) Service(ptr interface{}) error {
+	return c.ServiceNamed("", ptr)
+}
+
+// Resolve service into pointer by name, see Service
+func (c *Context) ServiceNamed(name string, ptr interface{}) error {
+	v := reflect.ValueOf(ptr)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		panic(fmt.Errorf("%v must be a non-nil pointer", v.Type()))
+	}
+	value, err := c.App.services.resolve(c, name, v.Elem().Type())
+	if err != nil {
+		return err
+	}
+	v.Elem().Set(value)
+	return nil
+}
@@ -0,0 +1,94 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type containerRepo struct {
+	id       int
+	disposed bool
+}
+
+func (r *containerRepo) Dispose() {
+	r.disposed = true
+}
+
+type containerConfig struct {
+	Name string
+}
+
+var containerRepos []*containerRepo
+
+type containerController struct {
+	Method
+	Config *containerConfig `inject:""`
+	Repo   *containerRepo   `inject:""`
+	Repo2  *containerRepo   `inject:""`
+	Label  string           `inject:"label"`
+}
+
+func (co *containerController) Get() {
+	if co.Config.Name != "test" || co.Repo == nil || co.Repo != co.Repo2 || co.Label != "hello" {
+		t := co.C.Pub.Data["t"].(*testing.T)
+		t.Fail()
+	}
+	co.C.Fmt().Print("ok")
+}
+
+func TestContainer(t *testing.T) {
+	App := NewApp()
+
+	App.Debug = true
+
+	count := 0
+
+	App.Services().Instance(&containerConfig{"test"}).
+		Provide(PerRequest, func(c *Context) *containerRepo {
+			count++
+			repo := &containerRepo{id: count}
+			containerRepos = append(containerRepos, repo)
+			return repo
+		}).
+		ProvideNamed("label", Transient, func() string {
+			return "hello"
+		}).
+		ProvideNamed("broken", Transient, func() (string, error) {
+			return "", errors.New("broken")
+		})
+
+	App.TestView = RouteHandlerFunc(func(c *Context) {
+		c.Pub.Data["t"] = t
+		c.RouteDealer(&containerController{})
+
+		// Request scoped services live until the request ends
+		if len(containerRepos) != 1 || containerRepos[0].disposed {
+			t.Fail()
+		}
+
+		var label string
+		if c.ServiceNamed("label", &label) != nil || label != "hello" {
+			t.Fail()
+		}
+
+		if c.ServiceNamed("broken", &label) == nil {
+			t.Fail()
+		}
+
+		var repo *containerRepo
+		if c.Service(&repo) != nil || repo.id != 1 || repo.disposed {
+			t.Fail()
+		}
+	})
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	http.Get(ts.URL)
+
+	if len(containerRepos) != 1 || !containerRepos[0].disposed {
+		t.Fail()
+	}
+}
@@ -1,6 +1,6 @@
-package core
-
-func ExampleContext_Header(c *Context) {
-	// Set Header
-	c.Res.Header().Set("Location", "/world/")
-}
+package core
+
+func ExampleContext_Header(c *Context) {
+	// Set Header
+	c.Res.Header().Set("Location", "/world/")
+}
@@ -0,0 +1,271 @@
+/*
+In-process Test Harness for core.App.
+
+Requests are served by calling App.ServeHTTP directly, no network is involved.
+
+	cl := coretest.New(t, app)
+	cl.PostForm("/login", url.Values{"user": {"gorail"}}).Status(303).HasCookie("__session")
+	cl.Get("/").Status(200).BodyContains("Hello").SessionKey("user", "gorail")
+
+A Client is not safe for concurrent use.
+*/
+package coretest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorail/core"
+)
+
+// Test Client, keeps cookies between requests.
+type Client struct {
+	t      testing.TB
+	App    *core.App
+	Jar    http.CookieJar
+	host   string
+	secure bool
+	header http.Header
+	cur    *Response
+}
+
+// Construct New Client, App.TestInspector is replaced.
+func New(t testing.TB, app *core.App) *Client {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cl := &Client{
+		t:      t,
+		App:    app,
+		Jar:    jar,
+		host:   "example.com",
+		header: http.Header{},
+	}
+
+	app.TestInspector = inspector{cl}
+
+	return cl
+}
+
+// Set Host (Virtual Host), default is "example.com"
+func (cl *Client) Host(host string) *Client {
+	cl.host = host
+	return cl
+}
+
+// Serve requests as AppSecure (https)
+func (cl *Client) Secure() *Client {
+	cl.secure = true
+	return cl
+}
+
+// Serve requests as App (http)
+func (cl *Client) Insecure() *Client {
+	cl.secure = false
+	return cl
+}
+
+// Set Header sent with every request
+func (cl *Client) Header(key, value string) *Client {
+	cl.header.Set(key, value)
+	return cl
+}
+
+// Simulate a reverse proxy, sets 'X-Forwarded-For' and 'X-Forwarded-Host'.
+// Leave host blank to keep the current host.
+func (cl *Client) Forwarded(remoteIp, host string) *Client {
+	cl.header.Set("X-Forwarded-For", remoteIp)
+	if host != "" {
+		cl.header.Set("X-Forwarded-Host", host)
+	}
+	return cl
+}
+
+func (cl *Client) url(path string) *url.URL {
+	scheme := "http"
+	if cl.secure {
+		scheme = "https"
+	}
+	u, err := url.Parse(scheme + "://" + cl.host + path)
+	if err != nil {
+		cl.t.Fatal(err)
+	}
+	return u
+}
+
+// Test Request
+type Request struct {
+	cl  *Client
+	Req *http.Request
+}
+
+// Construct New Request
+func (cl *Client) NewRequest(method, path string) *Request {
+	u := cl.url(path)
+	req := httptest.NewRequest(method, u.String(), nil)
+	req.Host = cl.host
+	for key, values := range cl.header {
+		req.Header[key] = values
+	}
+	return &Request{cl, req}
+}
+
+// Set Header
+func (r *Request) Header(key, value string) *Request {
+	r.Req.Header.Set(key, value)
+	return r
+}
+
+// Set Body and Content-Type
+func (r *Request) Body(contentType string, body []byte) *Request {
+	r.Req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.Req.ContentLength = int64(len(body))
+	r.Req.Header.Set("Content-Type", contentType)
+	return r
+}
+
+// Set url-encoded form as Body
+func (r *Request) Form(values url.Values) *Request {
+	return r.Body("application/x-www-form-urlencoded", []byte(values.Encode()))
+}
+
+// Set Json as Body
+func (r *Request) Json(v interface{}) *Request {
+	b, err := json.Marshal(v)
+	if err != nil {
+		r.cl.t.Fatal(err)
+	}
+	return r.Body("application/json", b)
+}
+
+// File for multipart body
+type File struct {
+	Name    string
+	Content []byte
+}
+
+// Set multipart form as Body
+func (r *Request) Multipart(fields map[string]string, files map[string]File) *Request {
+	buf := &bytes.Buffer{}
+	w := multipart.NewWriter(buf)
+	for name, value := range fields {
+		w.WriteField(name, value)
+	}
+	for name, file := range files {
+		part, err := w.CreateFormFile(name, file.Name)
+		if err != nil {
+			r.cl.t.Fatal(err)
+		}
+		part.Write(file.Content)
+	}
+	if err := w.Close(); err != nil {
+		r.cl.t.Fatal(err)
+	}
+	return r.Body(w.FormDataContentType(), buf.Bytes())
+}
+
+// Serve Request in-process and return the Response
+func (r *Request) Do() *Response {
+	cl := r.cl
+	u := cl.url(r.Req.URL.RequestURI())
+
+	for _, cookie := range cl.Jar.Cookies(u) {
+		r.Req.AddCookie(cookie)
+	}
+
+	rec := httptest.NewRecorder()
+	res := &Response{t: cl.t, Recorder: rec}
+	cl.cur = res
+
+	if cl.secure {
+		core.AppSecure{App: cl.App}.ServeHTTP(rec, r.Req)
+	} else {
+		cl.App.ServeHTTP(rec, r.Req)
+	}
+
+	cl.cur = nil
+	cl.Jar.SetCookies(u, rec.Result().Cookies())
+
+	return res
+}
+
+// Shortcut to NewRequest("GET", path).Do()
+func (cl *Client) Get(path string) *Response {
+	return cl.NewRequest("GET", path).Do()
+}
+
+// Shortcut to NewRequest("HEAD", path).Do()
+func (cl *Client) Head(path string) *Response {
+	return cl.NewRequest("HEAD", path).Do()
+}
+
+// Shortcut to NewRequest("DELETE", path).Do()
+func (cl *Client) Delete(path string) *Response {
+	return cl.NewRequest("DELETE", path).Do()
+}
+
+// Shortcut to NewRequest("POST", path).Form(values).Do()
+func (cl *Client) PostForm(path string, values url.Values) *Response {
+	return cl.NewRequest("POST", path).Form(values).Do()
+}
+
+// Shortcut to NewRequest("POST", path).Json(v).Do()
+func (cl *Client) PostJson(path string, v interface{}) *Response {
+	return cl.NewRequest("POST", path).Json(v).Do()
+}
+
+// Shortcut to NewRequest("POST", path).Multipart(fields, files).Do()
+func (cl *Client) PostMultipart(path string, fields map[string]string, files map[string]File) *Response {
+	return cl.NewRequest("POST", path).Multipart(fields, files).Do()
+}
+
+// Snapshot of MethodHtml5 buffers, taken before they are written to the client.
+type Html5 struct {
+	HtmlAttr, Title, Head, BodyAttr, BodyHeader, BodyContent, BodyFooter, BodyJs string
+}
+
+// Get body, header + content + footer
+func (h *Html5) Body() string {
+	return h.BodyHeader + h.BodyContent + h.BodyFooter
+}
+
+type inspector struct {
+	cl *Client
+}
+
+func (in inspector) InspectContext(c *core.Context) {
+	if in.cl.cur == nil {
+		return
+	}
+	in.cl.cur.Context = c
+}
+
+func (in inspector) InspectHtml5(h core.HtmlBuffer, c *core.Context) {
+	if in.cl.cur == nil {
+		return
+	}
+	in.cl.cur.Html5 = &Html5{
+		HtmlAttr:    h.HtmlAttr().String(),
+		Title:       h.Title().String(),
+		Head:        h.Head().String(),
+		BodyAttr:    h.BodyAttr().String(),
+		BodyHeader:  h.BodyHeader().String(),
+		BodyContent: h.BodyContent().String(),
+		BodyFooter:  h.BodyFooter().String(),
+		BodyJs:      h.BodyJs().String(),
+	}
+}
+
+func trimLn(s string) string {
+	return strings.TrimRight(s, "\r\n")
+}
@@ -0,0 +1,80 @@
+package coretest
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/gorail/core"
+)
+
+type html5Dummy struct {
+	core.MethodHtml5
+}
+
+func (me *html5Dummy) Get() {
+	me.Title("Hello")
+	me.Head(`<meta name="test">`)
+	me.BodyContent("<h1>World</h1>")
+}
+
+func TestClient(t *testing.T) {
+	app := core.NewApp()
+
+	app.Router("main").RegisterFuncMap(core.FuncMap{
+		`^/login$`: func(c *core.Context) {
+			c.Session().Adv().Set("user", c.Form().Value.Get("user"))
+			c.Session().Adv().Save()
+			c.Url().Redirect("/")
+		},
+		`^/whoami$`: func(c *core.Context) {
+			user, _ := c.Session().Adv().Get("user").(string)
+			c.Fmt().Print(user, " ", c.RemoteAddr(), " ", c.Req.Host, " ", c.Is().Secure())
+		},
+		`^/json$`: func(c *core.Context) {
+			data := map[string]string{}
+			c.Json().DecodeReqBody(&data)
+			c.Json().Send(data)
+		},
+		`^/upload$`: func(c *core.Context) {
+			file := c.Form().GetFile("file")
+			c.Fmt().Print(c.Form().Value.Get("title"), " ", file.Filename)
+		},
+		`^/cookie$`: func(c *core.Context) {
+			c.Cookie("pref").Value("dark").SaveRes()
+			c.Fmt().Print("ok")
+		},
+	})
+	app.Router("main").Register(`^/html5$`, &html5Dummy{})
+
+	cl := New(t, app)
+
+	cl.PostForm("/login", url.Values{"user": {"gorail"}}).
+		Status(303).
+		HasCookie(app.SessionCookieName.String(), "").
+		SessionKey("user", "gorail")
+
+	cl.Get("/whoami").Status(200).Body("gorail 192.0.2.1 example.com false")
+
+	cl.Forwarded("10.0.0.1", "www.example.com").Secure().
+		Get("/whoami").Body("gorail 10.0.0.1 www.example.com true")
+
+	result := map[string]string{}
+	cl.PostJson("/json", map[string]string{"hello": "world"}).Status(200).Json(&result)
+	if result["hello"] != "world" {
+		t.Fail()
+	}
+
+	cl.PostMultipart("/upload", map[string]string{"title": "Report"}, map[string]File{
+		"file": {"report.txt", []byte("content")},
+	}).Body("Report report.txt")
+
+	app.CookieHashKey = []byte("0123456789abcdef0123456789abcdef")
+	cl.Get("/cookie").Cookie("pref", "dark")
+
+	cl.Get("/html5").
+		Status(200).
+		Html5Title("Hello").
+		Html5HeadContains(`name="test"`).
+		Html5BodyContains("<h1>World</h1>").
+		HeaderContains("Content-Type", "text/html")
+}
@@ -0,0 +1,201 @@
+package coretest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gorail/core"
+)
+
+// Test Response, assertions report to testing.TB and return the Response for chaining.
+type Response struct {
+	t        testing.TB
+	Recorder *httptest.ResponseRecorder
+	// Context of the request, nil if App did not complete the request.
+	Context *core.Context
+	// MethodHtml5 buffers, nil if MethodHtml5 was not used.
+	Html5 *Html5
+}
+
+func (r *Response) errorf(format string, a ...interface{}) {
+	r.t.Helper()
+	r.t.Errorf(format, a...)
+}
+
+// Get Body as String
+func (r *Response) BodyString() string {
+	return r.Recorder.Body.String()
+}
+
+// Get Cookie by name, nil if not set.
+func (r *Response) GetCookie(name string) *http.Cookie {
+	for _, cookie := range r.Recorder.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+// Assert Status Code
+func (r *Response) Status(code int) *Response {
+	r.t.Helper()
+	if r.Recorder.Code != code {
+		r.errorf("status: expected %d, got %d", code, r.Recorder.Code)
+	}
+	return r
+}
+
+// Assert Header Value
+func (r *Response) Header(key, value string) *Response {
+	r.t.Helper()
+	if got := r.Recorder.Header().Get(key); got != value {
+		r.errorf("header %s: expected %q, got %q", key, value, got)
+	}
+	return r
+}
+
+// Assert Header contains substr
+func (r *Response) HeaderContains(key, substr string) *Response {
+	r.t.Helper()
+	if got := r.Recorder.Header().Get(key); !strings.Contains(got, substr) {
+		r.errorf("header %s: expected to contain %q, got %q", key, substr, got)
+	}
+	return r
+}
+
+// Assert Body
+func (r *Response) Body(body string) *Response {
+	r.t.Helper()
+	if got := r.BodyString(); got != body {
+		r.errorf("body: expected %q, got %q", body, got)
+	}
+	return r
+}
+
+// Assert Body contains substr
+func (r *Response) BodyContains(substr string) *Response {
+	r.t.Helper()
+	if got := r.BodyString(); !strings.Contains(got, substr) {
+		r.errorf("body: expected to contain %q, got %q", substr, got)
+	}
+	return r
+}
+
+// Decode Json Body into v
+func (r *Response) Json(v interface{}) *Response {
+	r.t.Helper()
+	if err := json.Unmarshal(r.Recorder.Body.Bytes(), v); err != nil {
+		r.errorf("json: %v", err)
+	}
+	return r
+}
+
+// Assert Cookie is set, leave value blank to skip value check.
+// Value is compared as sent, signed cookies can be checked with Cookie instead.
+func (r *Response) HasCookie(name, value string) *Response {
+	r.t.Helper()
+	cookie := r.GetCookie(name)
+	if cookie == nil {
+		r.errorf("cookie %s: not set", name)
+		return r
+	}
+	if value != "" && cookie.Value != value {
+		r.errorf("cookie %s: expected %q, got %q", name, value, cookie.Value)
+	}
+	return r
+}
+
+// Assert Cookie value, decoded with core.Context.Cookie (signed or unsigned).
+func (r *Response) Cookie(name, value string) *Response {
+	r.t.Helper()
+	if r.Context == nil {
+		r.errorf("cookie %s: no context", name)
+		return r
+	}
+	cookie := r.GetCookie(name)
+	if cookie == nil {
+		r.errorf("cookie %s: not set", name)
+		return r
+	}
+	r.Context.Req.Header.Del("Cookie")
+	r.Context.Req.AddCookie(cookie)
+	decoded, err := r.Context.Cookie(name).Get()
+	if err != nil {
+		r.errorf("cookie %s: %v", name, err)
+		return r
+	}
+	if decoded.Value != value {
+		r.errorf("cookie %s: expected %q, got %q", name, value, decoded.Value)
+	}
+	return r
+}
+
+// Assert Session, compared with reflect.DeepEqual
+func (r *Response) Session(expected interface{}) *Response {
+	r.t.Helper()
+	if r.Context == nil {
+		r.errorf("session: no context")
+		return r
+	}
+	if got := r.Context.Session().Get(); !reflect.DeepEqual(got, expected) {
+		r.errorf("session: expected %#v, got %#v", expected, got)
+	}
+	return r
+}
+
+// Assert Session Key (SessionAdv), compared with reflect.DeepEqual
+func (r *Response) SessionKey(key string, expected interface{}) *Response {
+	r.t.Helper()
+	if r.Context == nil {
+		r.errorf("session %s: no context", key)
+		return r
+	}
+	if got := r.Context.Session().Adv().Get(key); !reflect.DeepEqual(got, expected) {
+		r.errorf("session %s: expected %#v, got %#v", key, expected, got)
+	}
+	return r
+}
+
+// Assert MethodHtml5 Title, trailing new line is ignored.
+func (r *Response) Html5Title(title string) *Response {
+	r.t.Helper()
+	if r.Html5 == nil {
+		r.errorf("html5: MethodHtml5 was not rendered")
+		return r
+	}
+	if got := trimLn(r.Html5.Title); got != title {
+		r.errorf("html5 title: expected %q, got %q", title, got)
+	}
+	return r
+}
+
+// Assert MethodHtml5 Head contains substr
+func (r *Response) Html5HeadContains(substr string) *Response {
+	r.t.Helper()
+	if r.Html5 == nil {
+		r.errorf("html5: MethodHtml5 was not rendered")
+		return r
+	}
+	if !strings.Contains(r.Html5.Head, substr) {
+		r.errorf("html5 head: expected to contain %q, got %q", substr, r.Html5.Head)
+	}
+	return r
+}
+
+// Assert MethodHtml5 Body (header, content and footer) contains substr
+func (r *Response) Html5BodyContains(substr string) *Response {
+	r.t.Helper()
+	if r.Html5 == nil {
+		r.errorf("html5: MethodHtml5 was not rendered")
+		return r
+	}
+	if body := r.Html5.Body(); !strings.Contains(body, substr) {
+		r.errorf("html5 body: expected to contain %q, got %q", substr, body)
+	}
+	return r
+}
@@ -0,0 +1,397 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Request Headers redacted from crash reports.
+var RedactHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Api-Key",
+	"X-Auth-Token",
+	"X-Csrf-Token",
+}
+
+// Form fields and query parameters redacted from crash reports, case insensitive substring match.
+var RedactFormFields = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"apikey",
+	"api_key",
+	"card",
+	"cvv",
+}
+
+const redactedValue = "[REDACTED]"
+
+// Request part of Crash Report, sensitive values are redacted.
+type CrashRequest struct {
+	Proto         string      `json:"proto"`
+	Method        string      `json:"method"`
+	Host          string      `json:"host"`
+	Path          string      `json:"path"`
+	Query         string      `json:"query"`
+	RemoteAddr    string      `json:"remoteAddr"`
+	Header        http.Header `json:"header"`
+	Form          url.Values  `json:"form"`
+	MultipartForm url.Values  `json:"multipartForm,omitempty"`
+}
+
+// Structured Crash Report
+type CrashReport struct {
+	Time        time.Time    `json:"time"`
+	App         string       `json:"app"`
+	RequestId   string       `json:"requestId"`
+	GoroutineId int64        `json:"goroutineId"`
+	Panic       string       `json:"panic"`
+	Stack       string       `json:"stack"`
+	Request     CrashRequest `json:"request"`
+}
+
+// Construct New Crash Report from recovered value and stack.
+func NewCrashReport(c *Context, r interface{}, stack []byte) *CrashReport {
+	c.Req.ParseMultipartForm(c.App.FormMemoryLimit)
+
+	report := &CrashReport{
+		Time:        time.Now(),
+		App:         c.App.Name,
+		RequestId:   c.RequestId(),
+		GoroutineId: goroutineId(stack),
+		Panic:       fmt.Sprint(r),
+		Stack:       string(stack),
+		Request: CrashRequest{
+			Proto:      c.Req.Proto,
+			Method:     c.Req.Method,
+			Host:       c.Req.Host,
+			Path:       c.Req.URL.Path,
+			RemoteAddr: c.Req.RemoteAddr,
+			Header:     redactHeader(c.Req.Header),
+			Form:       redactValues(c.Req.Form),
+		},
+	}
+
+	if query, err := url.ParseQuery(c.Req.URL.RawQuery); err == nil {
+		report.Request.Query = redactValues(query).Encode()
+	}
+
+	if c.Req.MultipartForm != nil {
+		report.Request.MultipartForm = redactValues(url.Values(c.Req.MultipartForm.Value))
+	}
+
+	return report
+}
+
+// Fingerprint, identical panics from the same code path share the same fingerprint.
+func (cr *CrashReport) Fingerprint() string {
+	buf := &bytes.Buffer{}
+	buf.WriteString(cr.Panic)
+	for _, line := range strings.Split(cr.Stack, "\n") {
+		// Function names only, skip goroutine header and file lines (contain offsets).
+		if line == "" || strings.HasPrefix(line, "\t") || strings.HasPrefix(line, "goroutine ") {
+			continue
+		}
+		buf.WriteString("\n")
+		if pos := strings.LastIndex(line, "("); pos != -1 {
+			line = line[:pos]
+		}
+		buf.WriteString(line)
+	}
+	return buf.String()
+}
+
+// Write Crash Report in human readable form.
+func (cr *CrashReport) WriteTo(w io.Writer) (int64, error) {
+	buf := &bytes.Buffer{}
+
+	fmt.Fprintf(buf, "\r\n%s, %s, %s, %s, ?%s IP:%s\r\n",
+		cr.Request.Proto, cr.Request.Method,
+		cr.Request.Host, cr.Request.Path,
+		cr.Request.Query, cr.Request.RemoteAddr)
+
+	fmt.Fprintf(buf, "\r\n%s\r\n\r\n%s", cr.Panic, cr.Stack)
+
+	fmt.Fprintln(buf, "\r\nApp:", cr.App, "Request Id:", cr.RequestId, "Goroutine:", cr.GoroutineId)
+
+	fmt.Fprintln(buf, "\r\nRequest Header:")
+	fmt.Fprintln(buf, cr.Request.Header)
+
+	fmt.Fprintln(buf, "\r\nForm Values:")
+	fmt.Fprintln(buf, cr.Request.Form)
+
+	fmt.Fprintln(buf, "\r\nForm Values (Multipart):")
+	fmt.Fprintln(buf, cr.Request.MultipartForm)
+
+	fmt.Fprintln(buf, "\r\nTime:")
+	fmt.Fprintln(buf, cr.Time)
+
+	return buf.WriteTo(w)
+}
+
+func redactHeader(header http.Header) http.Header {
+	out := http.Header{}
+	for name, values := range header {
+		out[name] = values
+		for _, redact := range RedactHeaders {
+			if http.CanonicalHeaderKey(redact) == name {
+				out[name] = []string{redactedValue}
+				break
+			}
+		}
+	}
+	return out
+}
+
+func redactValues(values url.Values) url.Values {
+	if values == nil {
+		return nil
+	}
+	out := url.Values{}
+	for name, value := range values {
+		out[name] = value
+		lname := strings.ToLower(name)
+		for _, redact := range RedactFormFields {
+			if strings.Contains(lname, redact) {
+				out[name] = []string{redactedValue}
+				break
+			}
+		}
+	}
+	return out
+}
+
+// Parse goroutine id from the first line of stack, e.g. "goroutine 7 [running]:"
+func goroutineId(stack []byte) int64 {
+	line := string(stack)
+	if pos := strings.Index(line, "\n"); pos != -1 {
+		line = line[:pos]
+	}
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != "goroutine" {
+		return 0
+	}
+	id, _ := strconv.ParseInt(fields[1], 10, 64)
+	return id
+}
+
+// Pass panic on to multiple Panic Handlers.
+type PanicMulti []PanicHandler
+
+func (p PanicMulti) Panic(c *Context, r interface{}, stack []byte) {
+	for _, handler := range p {
+		handler.Panic(c, r, stack)
+	}
+}
+
+// Deduplicate and Rate Limit panics before passing on to Handler.
+type PanicLimiter struct {
+	Handler PanicHandler
+	// Identical panics within Window are only reported once.
+	Window time.Duration
+	// Maximum reports per Interval, zero means no limit.
+	Max int
+	// Defaults to DefaultPanicLimitInterval if zero.
+	Interval time.Duration
+
+	mu         sync.Mutex
+	seen       map[string]time.Time
+	start      time.Time
+	count      int
+	suppressed int64
+}
+
+// Interval of PanicLimiter if not set
+var DefaultPanicLimitInterval = time.Minute
+
+// Construct New Panic Limiter
+func NewPanicLimiter(handler PanicHandler, window time.Duration, max int, interval time.Duration) *PanicLimiter {
+	return &PanicLimiter{Handler: handler, Window: window, Max: max, Interval: interval}
+}
+
+func (p *PanicLimiter) allow(fingerprint string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+
+	if p.seen == nil {
+		p.seen = map[string]time.Time{}
+	}
+
+	for key, t := range p.seen {
+		if now.Sub(t) >= p.Window {
+			delete(p.seen, key)
+		}
+	}
+
+	if _, ok := p.seen[fingerprint]; ok {
+		p.suppressed++
+		return false
+	}
+
+	if p.Max > 0 {
+		interval := p.Interval
+		if interval <= 0 {
+			interval = DefaultPanicLimitInterval
+		}
+		if now.Sub(p.start) >= interval {
+			p.start = now
+			p.count = 0
+		}
+		if p.count >= p.Max {
+			p.suppressed++
+			return false
+		}
+		p.count++
+	}
+
+	if p.Window > 0 {
+		p.seen[fingerprint] = now
+	}
+	return true
+}
+
+// Number of suppressed reports
+func (p *PanicLimiter) Suppressed() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.suppressed
+}
+
+func (p *PanicLimiter) Panic(c *Context, r interface{}, stack []byte) {
+	report := &CrashReport{Panic: fmt.Sprint(r), Stack: string(stack)}
+	if !p.allow(report.Fingerprint()) {
+		return
+	}
+	p.Handler.Panic(c, r, stack)
+}
+
+const panicJsonFileExt = ".jsonl"
+
+// Write crash reports as JSON lines to Path/Name.jsonl, rotated on MaxSize.
+type PanicJsonFile struct {
+	Path string
+	// File name without extension, default "panic"
+	Name string
+	// Rotate after MaxSize bytes, default 10MB
+	MaxSize int64
+	// Number of rotated files to keep, default 5
+	MaxFiles int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// Construct New Panic Json File
+func NewPanicJsonFile(path string) *PanicJsonFile {
+	return &PanicJsonFile{Path: path}
+}
+
+func (p *PanicJsonFile) filename(n int) string {
+	name := p.Name
+	if name == "" {
+		name = "panic"
+	}
+	if n > 0 {
+		name = fmt.Sprint(name, ".", n)
+	}
+	return filepath.Join(p.Path, name+panicJsonFileExt)
+}
+
+func (p *PanicJsonFile) rotate() error {
+	if p.file != nil {
+		p.file.Close()
+		p.file = nil
+	}
+
+	maxFiles := p.MaxFiles
+	if maxFiles <= 0 {
+		maxFiles = 5
+	}
+
+	os.Remove(p.filename(maxFiles))
+	for n := maxFiles - 1; n >= 0; n-- {
+		os.Rename(p.filename(n), p.filename(n+1))
+	}
+	return p.open()
+}
+
+func (p *PanicJsonFile) open() error {
+	file, err := os.OpenFile(p.filename(0), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+	p.file = file
+	p.size = info.Size()
+	return nil
+}
+
+func (p *PanicJsonFile) write(line []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.file == nil {
+		if err := p.open(); err != nil {
+			return err
+		}
+	}
+
+	maxSize := p.MaxSize
+	if maxSize <= 0 {
+		maxSize = 10 * 1024 * 1024
+	}
+
+	if p.size > 0 && p.size+int64(len(line)) > maxSize {
+		if err := p.rotate(); err != nil {
+			return err
+		}
+	}
+
+	num, err := p.file.Write(line)
+	p.size += int64(num)
+	return err
+}
+
+// Close current file
+func (p *PanicJsonFile) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.file == nil {
+		return nil
+	}
+	err := p.file.Close()
+	p.file = nil
+	return err
+}
+
+func (p *PanicJsonFile) Panic(c *Context, r interface{}, stack []byte) {
+	line, err := json.Marshal(NewCrashReport(c, r, stack))
+	if err != nil {
+		ErrPrintln(err)
+		return
+	}
+	if err = p.write(append(line, '\n')); err != nil {
+		ErrPrintln(err)
+	}
+}
@@ -0,0 +1,160 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCrashReport(t *testing.T) {
+	App := NewApp()
+
+	App.Debug = true
+
+	reports := []*CrashReport{}
+
+	App.PanicHandler = NewPanicLimiter(panicFunc(func(c *Context, r interface{}, stack []byte) {
+		reports = append(reports, NewCrashReport(c, r, stack))
+	}), time.Minute, 0, 0)
+
+	App.TestView = RouteHandlerFunc(func(c *Context) {
+		panic("crash")
+	})
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest("POST", ts.URL+"/?token=abc&page=1", strings.NewReader(url.Values{
+			"password": {"secret"},
+			"name":     {"gorail"},
+		}.Encode()))
+		Check(err)
+
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Authorization", "Basic Z29yYWls")
+		req.Header.Set("X-Request-Id", "abc123")
+
+		res, err := http.DefaultClient.Do(req)
+		Check(err)
+		res.Body.Close()
+
+		if res.StatusCode != 500 {
+			t.Fail()
+		}
+	}
+
+	if len(reports) != 1 {
+		t.Fatal("expected panic to be deduplicated")
+	}
+
+	report := reports[0]
+
+	if report.Panic != "crash" || report.RequestId != "abc123" || report.GoroutineId <= 0 || report.App != App.Name {
+		t.Fail()
+	}
+
+	if report.Request.Header.Get("Authorization") != redactedValue {
+		t.Fail()
+	}
+
+	if report.Request.Form.Get("password") != redactedValue || report.Request.Form.Get("name") != "gorail" {
+		t.Fail()
+	}
+
+	if !strings.Contains(report.Request.Query, "page=1") || strings.Contains(report.Request.Query, "abc") {
+		t.Fail()
+	}
+}
+
+func TestPanicLimiter(t *testing.T) {
+	count := 0
+
+	limiter := NewPanicLimiter(panicFunc(func(c *Context, r interface{}, stack []byte) {
+		count++
+	}), 0, 2, time.Minute)
+
+	for i := 0; i < 5; i++ {
+		limiter.Panic(nil, i, nil)
+	}
+
+	if count != 2 || limiter.Suppressed() != 3 {
+		t.Fail()
+	}
+	// Zero Interval falls back to DefaultPanicLimitInterval, Max still applies
+	count = 0
+	limiter = NewPanicLimiter(limiter.Handler, 0, 1, 0)
+
+	for i := 0; i < 3; i++ {
+		limiter.Panic(nil, i, nil)
+	}
+
+	if count != 1 || limiter.Suppressed() != 2 {
+		t.Fail()
+	}
+}
+
+func TestPanicJsonFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "core")
+	Check(err)
+	defer os.RemoveAll(dir)
+
+	App := NewApp()
+
+	sink := NewPanicJsonFile(dir)
+	sink.MaxSize = 1
+	sink.MaxFiles = 2
+	defer sink.Close()
+
+	App.PanicHandler = PanicMulti{sink}
+
+	App.TestView = RouteHandlerFunc(func(c *Context) {
+		panic("crash")
+	})
+
+	App.Debug = true
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	for i := 0; i < 4; i++ {
+		res, err := http.Get(ts.URL)
+		Check(err)
+		res.Body.Close()
+	}
+
+	for _, n := range []int{0, 1, 2} {
+		file, err := os.Open(sink.filename(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(nil, 1024*1024)
+		lines := 0
+		for scanner.Scan() {
+			report := CrashReport{}
+			Check(json.Unmarshal(scanner.Bytes(), &report))
+			if report.Panic != "crash" {
+				t.Fail()
+			}
+			lines++
+		}
+		file.Close()
+
+		if lines != 1 {
+			t.Fail()
+		}
+	}
+
+	if _, err := os.Stat(sink.filename(3)); !os.IsNotExist(err) {
+		t.Fail()
+	}
+}
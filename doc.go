@@ -1,16 +1,18 @@
-/*
-A Basic Web Framework, the Core of Gorail.
-
-It's built on top of the standard package 'net/http'.
-
-Installation:
-
-	go get github.com/gorail/core
-*/
-package core
-
-/*
-	Assert Serial Numbers
-	asn_Core_0001 : Method
-	asn_Core_0002 : Protocol
-*/
+/*
+A Basic Web Framework, the Core of Gorail.
+
+It's built on top of the standard package 'net/http'.
+
+Installation:
+
+	go get github.com/gorail/core
+*/
+package core
+
+/*
+	Assert Serial Numbers
+	asn_Core_0001 : Method
+	asn_Core_0002 : Protocol
+	asn_Core_0003 : MethodErr
+	asn_Core_0004 : Resource
+*/
@@ -1,158 +1,261 @@
-package core
-
-import (
-	"fmt"
-	"io"
-	"os"
-	"runtime/debug"
-	"time"
-)
-
-func printPanic(buf io.Writer, c *Context, r interface{}, stack []byte) {
-	printF := func(format string, a ...interface{}) {
-		fmt.Fprintf(buf, format, a...)
-	}
-
-	printLn := func(a ...interface{}) {
-		fmt.Fprintln(buf, a...)
-	}
-
-	printF("\r\n%s, %s, %s, %s, ?%s IP:%s\r\n",
-		c.Req.Proto, c.Req.Method,
-		c.Req.Host, c.Req.URL.Path,
-		c.Req.URL.RawQuery, c.Req.RemoteAddr)
-
-	printF("\r\n%s\r\n\r\n%s", r, stack)
-
-	printLn("\r\nRequest Header:")
-	printLn(c.Req.Header)
-
-	c.Req.ParseMultipartForm(c.App.FormMemoryLimit)
-
-	printLn("\r\nForm Values:")
-	printLn(c.Req.Form)
-
-	printLn("\r\nForm Values (Multipart):")
-	printLn(c.Req.MultipartForm)
-
-	printLn("\r\nTime:")
-	printLn(time.Now())
-}
-
-// Check for Error
-func (c *Context) Check(err error) {
-	Check(err)
-}
-
-// Check for Error
-func Check(err error) {
-	if err != nil {
-		panic(err)
-	}
-}
-
-type PanicHandler interface {
-	Panic(*Context, interface{}, []byte)
-}
-
-// Write error to Stderr.
-type PanicConsole struct{}
-
-func (_ PanicConsole) Panic(c *Context, r interface{}, stack []byte) {
-	ErrPrint(r, "\r\n", string(stack))
-}
-
-const panicFileExt = ".txt"
-
-// Write error to new file.
-type PanicFile struct {
-	Path string
-}
-
-func (p PanicFile) Panic(c *Context, r interface{}, stack []byte) {
-	filename := p.Path + fmt.Sprintf("/%d_%d", time.Now().Unix(), time.Now().UnixNano()) + panicFileExt
-	file, err := os.Create(filename)
-	if err != nil {
-		return
-	}
-	defer file.Close()
-	printPanic(file, c, r, stack)
-}
-
-var DefaultPanicHandler PanicHandler = PanicConsole{}
-
-type Errors struct {
-	E403 func(c *Context)
-	E404 func(c *Context)
-	E405 func(c *Context)
-	E500 func(c *Context)
-}
-
-// Execute Error 403 (Forbidden)
-func (c *Context) Error403() {
-	c.Pub.Status = 403
-	c.Pub.Errors.E403(c)
-	c.Terminate()
-}
-
-// Execute Error 404 (Not Found)
-func (c *Context) Error404() {
-	c.Pub.Status = 404
-	c.Pub.Errors.E404(c)
-	c.Terminate()
-}
-
-// Execute Error 405 (Method Not Allowed)
-func (c *Context) Error405() {
-	c.Pub.Status = 405
-	c.Pub.Errors.E405(c)
-	c.Terminate()
-}
-
-// Execute Error 500 (Internal Server Error)
-func (c *Context) Error500() {
-	c.Pub.Status = 500
-	c.Pub.Errors.E500(c)
-	c.Terminate()
-}
-
-// Custom String Data Type, Implement error interface.
-type ErrorStr string
-
-func (e ErrorStr) Error() string {
-	return "Error: " + string(e)
-}
-
-// Print formats using the default formats for its operands and writes to standard error output.
-// Spaces are added between operands when neither is a string.
-// It returns the number of bytes written and any write error encountered.
-func ErrPrint(a ...interface{}) {
-	fmt.Fprint(os.Stderr, a...)
-}
-
-// Printf formats according to a format specifier and writes to standard error output.
-// It returns the number of bytes written and any write error encountered.
-func ErrPrintf(format string, a ...interface{}) {
-	fmt.Fprintf(os.Stderr, format, a...)
-}
-
-// Println formats using the default formats for its operands and writes to standard error output.
-// Spaces are always added between operands and a newline is appended.
-// It returns the number of bytes written and any write error encountered.
-func ErrPrintln(a ...interface{}) {
-	fmt.Fprintln(os.Stderr, a...)
-}
-
-func (c *Context) recover() {
-	if r := recover(); r != nil {
-		stack := debug.Stack()
-		DefaultPanicHandler.Panic(c, r, stack)
-		if c.App.Debug {
-			c.Pub.Status = 500
-			c.Fmt().Println("500 Internal Server Error")
-			printPanic(c.Res, c, r, stack)
-			return
-		}
-		c.Error500()
-	}
-}
+package core
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+func printPanic(buf io.Writer, c *Context, r interface{}, stack []byte) {
+	NewCrashReport(c, r, stack).WriteTo(buf)
+}
+
+// Check for Error
+func (c *Context) Check(err error) {
+	Check(err)
+}
+
+// Check for Error
+func Check(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+type PanicHandler interface {
+	Panic(*Context, interface{}, []byte)
+}
+
+// Write error to Stderr.
+type PanicConsole struct{}
+
+func (_ PanicConsole) Panic(c *Context, r interface{}, stack []byte) {
+	ErrPrint(r, "\r\n", string(stack))
+}
+
+const panicFileExt = ".txt"
+
+// Write error to new file.
+type PanicFile struct {
+	// OS directory, must be writable
+	Path string
+}
+
+func (p PanicFile) Panic(c *Context, r interface{}, stack []byte) {
+	filename := p.Path + fmt.Sprintf("/%d_%d", time.Now().Unix(), time.Now().UnixNano()) + panicFileExt
+	file, err := os.Create(filename)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	printPanic(file, c, r, stack)
+}
+
+var DefaultPanicHandler PanicHandler = PanicConsole{}
+
+// Get Panic Handler, App.PanicHandler or DefaultPanicHandler if nil.
+func (app *App) panicHandler() PanicHandler {
+	if app.PanicHandler != nil {
+		return app.PanicHandler
+	}
+	return DefaultPanicHandler
+}
+
+// Error Handler, err is the originating error and may be nil.
+type ErrorHandler func(c *Context, err error)
+
+// Status class keys, fallback for any 4xx or 5xx status code without a handler.
+const (
+	Error4xx = 4
+	Error5xx = 5
+)
+
+type Errors struct {
+	E403 func(c *Context)
+	E404 func(c *Context)
+	E405 func(c *Context)
+	E500 func(c *Context)
+	// Request level handlers, keyed by status code or class (Error4xx, Error5xx)
+	Handlers map[int]ErrorHandler
+}
+
+// Register Error Handler on request level, use status code or class (Error4xx, Error5xx) as code.
+func (e *Errors) Register(code int, handler ErrorHandler) {
+	if e.Handlers == nil {
+		e.Handlers = map[int]ErrorHandler{}
+	}
+	e.Handlers[code] = handler
+}
+
+// Register Error Handler on application level, use status code or class (Error4xx, Error5xx) as code.
+func (app *App) RegisterError(code int, handler ErrorHandler) {
+	app.errorHandlersSync.Lock()
+	defer app.errorHandlersSync.Unlock()
+	app.errorHandlers[code] = handler
+}
+
+func (app *App) errorHandler(code int) ErrorHandler {
+	app.errorHandlersSync.RLock()
+	defer app.errorHandlersSync.RUnlock()
+	return app.errorHandlers[code]
+}
+
+// Lookup order: request code, application code, request class, application class and than DefaultErrorHandler.
+func (c *Context) errorHandler(code int) ErrorHandler {
+	for _, key := range []int{code, code / 100} {
+		if handler := c.Pub.Errors.Handlers[key]; handler != nil {
+			return handler
+		}
+		if handler := c.App.errorHandler(key); handler != nil {
+			return handler
+		}
+	}
+	return DefaultErrorHandler
+}
+
+// Execute Error by status code, err is passed on to the error handler and may be nil.
+func (c *Context) Error(code int, err error) {
+	c.Pub.Status = code
+	c.pri.err = err
+
+	var legacy func(c *Context)
+	switch code {
+	case 403:
+		legacy = c.Pub.Errors.E403
+	case 404:
+		legacy = c.Pub.Errors.E404
+	case 405:
+		legacy = c.Pub.Errors.E405
+	case 500:
+		legacy = c.Pub.Errors.E500
+	}
+
+	if legacy != nil {
+		legacy(c)
+	} else {
+		c.errorHandler(code)(c, err)
+	}
+	c.Terminate()
+}
+
+// Get the error passed on to Error, nil if there is none.
+func (c *Context) ErrorCause() error {
+	return c.pri.err
+}
+
+// Execute Error 403 (Forbidden)
+func (c *Context) Error403() {
+	c.Error(403, nil)
+}
+
+// Execute Error 404 (Not Found)
+func (c *Context) Error404() {
+	c.Error(404, nil)
+}
+
+// Execute Error 405 (Method Not Allowed)
+func (c *Context) Error405() {
+	c.Error(405, nil)
+}
+
+// Execute Error 500 (Internal Server Error)
+func (c *Context) Error500() {
+	c.Error(500, nil)
+}
+
+// Localised status message, e.g. "404 Not Found"
+func (c *Context) errorMessage(code int) string {
+	msg := c.Lang().Key(fmt.Sprint("err", code))
+	if msg == "" {
+		msg = fmt.Sprint(code, " ", http.StatusText(code))
+	}
+	return msg
+}
+
+// Default Error Handler, problem+json or Json for API Clients otherwise HTML.
+var DefaultErrorHandler ErrorHandler = func(c *Context, err error) {
+	if strings.Contains(c.Req.Header.Get("Accept"), "application/problem+json") {
+		ProblemErrorHandler(c, err)
+		return
+	}
+
+	msg := c.errorMessage(c.Pub.Status)
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		httpErr = &HTTPError{}
+	}
+
+	if c.Is().JsonAccepted() {
+		out := map[string]interface{}{
+			"status": c.Pub.Status,
+			"error":  msg,
+		}
+		if httpErr.Message != "" {
+			out["message"] = httpErr.Message
+		}
+		if httpErr.Details != nil {
+			out["details"] = httpErr.Details
+		}
+		c.Res.Header().Set("Content-Type", "application/json; charset=utf-8")
+		c.Json().Send(out)
+		return
+	}
+
+	c.Res.Header().Set("Content-Type", "text/html; charset=utf-8")
+	c.Fmt().Print("<h1>", html.EscapeString(msg), "</h1>")
+	if httpErr.Message != "" {
+		c.Fmt().Print("<p>", html.EscapeString(httpErr.Message), "</p>")
+	}
+}
+
+// Custom String Data Type, Implement error interface.
+type ErrorStr string
+
+func (e ErrorStr) Error() string {
+	return "Error: " + string(e)
+}
+
+// Print formats using the default formats for its operands and writes to standard error output.
+// Spaces are added between operands when neither is a string.
+// It returns the number of bytes written and any write error encountered.
+func ErrPrint(a ...interface{}) {
+	fmt.Fprint(os.Stderr, a...)
+}
+
+// Printf formats according to a format specifier and writes to standard error output.
+// It returns the number of bytes written and any write error encountered.
+func ErrPrintf(format string, a ...interface{}) {
+	fmt.Fprintf(os.Stderr, format, a...)
+}
+
+// Println formats using the default formats for its operands and writes to standard error output.
+// Spaces are always added between operands and a newline is appended.
+// It returns the number of bytes written and any write error encountered.
+func ErrPrintln(a ...interface{}) {
+	fmt.Fprintln(os.Stderr, a...)
+}
+
+func (c *Context) recover() {
+	if r := recover(); r != nil {
+		stack := debug.Stack()
+		c.App.panicHandler().Panic(c, r, stack)
+		if c.App.Debug {
+			c.Pub.Status = 500
+			c.Fmt().Println("500 Internal Server Error")
+			printPanic(c.Res, c, r, stack)
+			return
+		}
+		err, ok := r.(error)
+		if !ok {
+			err = fmt.Errorf("%v", r)
+		}
+		c.Error(500, err)
+	}
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestError(t *testing.T) {
+	App := NewApp()
+
+	App.Debug = true
+
+	errTest := errors.New("test")
+
+	App.RegisterError(409, func(c *Context, err error) {
+		c.Pub.Group.Set("result", "APP409")
+	})
+
+	App.RegisterError(Error4xx, func(c *Context, err error) {
+		c.Pub.Group.Set("result", "APP4xx")
+	})
+
+	App.TestView = RouteHandlerFunc(func(c *Context) {
+		result := func() string {
+			return c.Pub.Group.Get("result")
+		}
+
+		c.Error(409, errTest)
+
+		if result() != "APP409" || c.Pub.Status != 409 || !c.Terminated() {
+			t.Fail()
+		}
+
+		c.Error(429, nil)
+
+		if result() != "APP4xx" {
+			t.Fail()
+		}
+
+		c.Pub.Errors.Register(409, func(c *Context, err error) {
+			if err != errTest {
+				t.Fail()
+			}
+			c.Pub.Group.Set("result", "REQ409")
+		})
+
+		c.Error(409, errTest)
+
+		if result() != "REQ409" {
+			t.Fail()
+		}
+
+		c.Pub.Errors.E404 = func(c *Context) {
+			if c.ErrorCause() != errTest {
+				t.Fail()
+			}
+			c.Pub.Group.Set("result", "E404")
+		}
+
+		c.Error(404, errTest)
+
+		if result() != "E404" {
+			t.Fail()
+		}
+	})
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	http.Get(ts.URL)
+}
+
+func TestErrorDefault(t *testing.T) {
+	App := NewApp()
+
+	App.Debug = true
+
+	App.TestView = RouteHandlerFunc(func(c *Context) {
+		c.Error(503, nil)
+	})
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL)
+	Check(err)
+
+	b, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	if res.StatusCode != 503 || string(b) != "<h1>503 Service Unavailable</h1>" {
+		t.Fail()
+	}
+
+	req, err := http.NewRequest("GET", ts.URL, nil)
+	Check(err)
+	req.Header.Set("Accept", "application/json")
+
+	res, err = http.DefaultClient.Do(req)
+	Check(err)
+
+	data := struct {
+		Status int    `json:"status"`
+		Error  string `json:"error"`
+	}{}
+
+	err = json.NewDecoder(res.Body).Decode(&data)
+	Check(err)
+	res.Body.Close()
+
+	if !strings.HasPrefix(res.Header.Get("Content-Type"), "application/json") {
+		t.Fail()
+	}
+
+	if data.Status != 503 || data.Error != "503 Service Unavailable" {
+		t.Fail()
+	}
+}
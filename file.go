@@ -1,18 +1,22 @@
-package core
-
-import (
-	"net/http"
-)
-
-// Create new File Server and returns RouteHandler
-func FileServer(dir string) RouteHandler {
-	return NoDirLock{RouteHandlerFunc(func(c *Context) {
-		c.Http().Exec(http.FileServer(http.Dir(dir)))
-	})}
-}
-
-func fileServer(path, dir string) RouteHandler {
-	return RouteHandlerFunc(func(c *Context) {
-		http.StripPrefix(path, http.FileServer(http.Dir(dir))).ServeHTTP(c.Res, c.Req)
-	})
-}
+package core
+
+import (
+	"io/fs"
+	"net/http"
+)
+
+// Create new File Server and returns RouteHandler, see StaticServer
+func FileServer(dir string) RouteHandler {
+	return NoDirLock{NewStaticServer(dir)}
+}
+
+// Create new File Server from File System (e.g. embed.FS) and returns RouteHandler
+func FileServerFS(fsys fs.FS) RouteHandler {
+	return NoDirLock{NewStaticServerFS(http.FS(fsys))}
+}
+
+func fileServer(path, dir string) RouteHandler {
+	return RouteHandlerFunc(func(c *Context) {
+		http.StripPrefix(path, NewStaticServer(dir)).ServeHTTP(c.Res, c.Req)
+	})
+}
@@ -0,0 +1,230 @@
+package core
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Currency symbols by ISO 4217 code, unknown codes are printed as is.
+var CurrencySymbols = map[string]string{
+	"GBP": "£",
+	"USD": "$",
+	"EUR": "€",
+	"JPY": "¥",
+	"CNY": "CN¥",
+	"INR": "₹",
+	"KRW": "₩",
+	"RUB": "₽",
+	"CHF": "CHF",
+	"AUD": "A$",
+	"CAD": "CA$",
+}
+
+// Minor unit digits by currency code, default 2
+var CurrencyDecimals = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"ISK": 0,
+	"BHD": 3,
+	"KWD": 3,
+}
+
+// Locale-aware formatting of time, numbers and currencies
+type Format struct {
+	c *Context
+}
+
+// Locale-aware formatting, see Lang keys timeFormat, numberDecimal, currencyFormat...
+func (c *Context) Format() Format {
+	return Format{c}
+}
+
+func (f Format) key(name, def string) string {
+	if value := f.c.Lang().Key(name); value != "" {
+		return value
+	}
+	return def
+}
+
+func (f Format) names(name string) []string {
+	return strings.Split(f.c.Lang().Key(name), ",")
+}
+
+// Format t in TimeLoc with Go layout, month and day names are localised.
+func (f Format) Layout(t time.Time, layout string) string {
+	if f.c.Pub.TimeLoc != nil {
+		t = t.In(f.c.Pub.TimeLoc)
+	}
+
+	tokens := []struct {
+		token string
+		names string
+		index int
+	}{
+		{"January", "months", int(t.Month()) - 1},
+		{"Monday", "days", int(t.Weekday())},
+		{"Jan", "monthsShort", int(t.Month()) - 1},
+		{"Mon", "daysShort", int(t.Weekday())},
+	}
+
+	out := &strings.Builder{}
+	chunk := 0
+	for i := 0; i < len(layout); {
+		matched := false
+		for _, token := range tokens {
+			if !strings.HasPrefix(layout[i:], token.token) {
+				continue
+			}
+			names := f.names(token.names)
+			// Keep Go's English name if the language has none
+			if token.index >= len(names) || names[token.index] == "" {
+				break
+			}
+			out.WriteString(t.Format(layout[chunk:i]))
+			out.WriteString(names[token.index])
+			i += len(token.token)
+			chunk = i
+			matched = true
+			break
+		}
+		if !matched {
+			i++
+		}
+	}
+	out.WriteString(t.Format(layout[chunk:]))
+	return out.String()
+}
+
+// Date and time, e.g. "Monday, 2 January 2006, 15:04"
+func (f Format) Time(t time.Time) string {
+	return f.Layout(t, f.key("timeFormat", time.RFC1123))
+}
+
+// Short date and time, e.g. "02/01/2006 15:04"
+func (f Format) ShortTime(t time.Time) string {
+	return f.Layout(t, f.key("shortTimeFormat", "2006-01-02 15:04"))
+}
+
+// Date, e.g. "Monday, 2 January 2006"
+func (f Format) Date(t time.Time) string {
+	return f.Layout(t, f.key("dateFormat", "2006-01-02"))
+}
+
+// Short date, e.g. "02/01/2006"
+func (f Format) ShortDate(t time.Time) string {
+	return f.Layout(t, f.key("shortDateFormat", "2006-01-02"))
+}
+
+// Clock time, e.g. "15:04" or "3:04PM"
+func (f Format) Kitchen(t time.Time) string {
+	return f.Layout(t, f.key("kitchenTimeFormat", time.Kitchen))
+}
+
+// Relative to now, e.g. "3 minutes ago" or "in 2 hours"
+func (f Format) Relative(t time.Time) string {
+	return f.RelativeTo(t, time.Now())
+}
+
+// Relative to now, e.g. "3 minutes ago" or "in 2 hours"
+func (f Format) RelativeTo(t, now time.Time) string {
+	d := t.Sub(now)
+	direction := "Ago"
+	if d > 0 {
+		direction = "In"
+	} else {
+		d = -d
+	}
+
+	if d < 10*time.Second {
+		return f.key("relNow", "now")
+	}
+
+	day := 24 * time.Hour
+	unit, count := "Second", int64(d/time.Second)
+	switch {
+	case d >= 365*day:
+		unit, count = "Year", int64(d/(365*day))
+	case d >= 30*day:
+		unit, count = "Month", int64(d/(30*day))
+	case d >= day:
+		unit, count = "Day", int64(d/day)
+	case d >= time.Hour:
+		unit, count = "Hour", int64(d/time.Hour)
+	case d >= time.Minute:
+		unit, count = "Minute", int64(d/time.Minute)
+	}
+
+	return f.c.Lang().T("rel"+unit+direction, map[string]interface{}{"count": count})
+}
+
+// Number with locale decimal and group separators
+func (f Format) Number(v float64, decimals int) string {
+	digits, negative := f.digits(v, decimals)
+	if negative {
+		return "-" + digits
+	}
+	return digits
+}
+
+// Integer with locale group separator
+func (f Format) Int(n int64) string {
+	return f.Number(float64(n), 0)
+}
+
+// Grouped digits of absolute value, negative is false if v rounds to zero.
+func (f Format) digits(v float64, decimals int) (string, bool) {
+	str := strconv.FormatFloat(math.Abs(v), 'f', decimals, 64)
+	// -0.001 rounds to "0.00"
+	negative := v < 0 && strings.Trim(str, "0.") != ""
+
+	integer, fraction := str, ""
+	if pos := strings.IndexByte(str, '.'); pos != -1 {
+		integer, fraction = str[:pos], str[pos+1:]
+	}
+
+	group := f.key("numberGroup", ",")
+	out := &strings.Builder{}
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			out.WriteString(group)
+		}
+		out.WriteRune(digit)
+	}
+	if fraction != "" {
+		out.WriteString(f.key("numberDecimal", "."))
+		out.WriteString(fraction)
+	}
+	return out.String(), negative
+}
+
+// Percentage of ratio, e.g. 0.256 to "25.6%"
+func (f Format) Percent(ratio float64, decimals int) string {
+	value, negative := f.digits(ratio*100, decimals)
+	out := strings.Replace(f.key("percentFormat", "{value}%"), "{value}", value, 1)
+	if negative {
+		return "-" + out
+	}
+	return out
+}
+
+// Amount in currency (ISO 4217 code), e.g. 1234.5 and "GBP" to "£1,234.50"
+func (f Format) Currency(amount float64, code string) string {
+	code = strings.ToUpper(code)
+	decimals, ok := CurrencyDecimals[code]
+	if !ok {
+		decimals = 2
+	}
+	symbol := CurrencySymbols[code]
+	if symbol == "" {
+		symbol = code
+	}
+
+	value, negative := f.digits(amount, decimals)
+	out := strings.NewReplacer("{value}", value, "{symbol}", symbol).Replace(f.key("currencyFormat", "{symbol}{value}"))
+	if negative {
+		return "-" + out
+	}
+	return out
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	App := NewApp()
+
+	loc, err := time.LoadLocation("Europe/Paris")
+	Check(err)
+
+	format := func(code string) Format {
+		c := &Context{App: App}
+		c.Pub.LangCode = code
+		c.Pub.TimeLoc = loc
+		return c.Format()
+	}
+
+	tm := time.Date(2021, 3, 1, 22, 30, 0, 0, time.UTC)
+
+	for _, test := range []struct{ result, expected string }{
+		{format("en-GB").Time(tm), "Monday,  1 March 2021, 23:30"},
+		{format("en-US").ShortDate(tm), "03/ 1/2021"},
+		{format("fr-FR").Date(tm), "lundi  1 mars 2021"},
+		{format("de-DE").Layout(tm, "Mon, 2. Jan 2006"), "Mo., 1. März 2021"},
+		{format("de-DE").Kitchen(tm), "23:30"},
+		{format("en-GB").RelativeTo(tm, tm.Add(3*time.Minute)), "3 minutes ago"},
+		{format("en-GB").RelativeTo(tm, tm.Add(-time.Hour)), "in 1 hour"},
+		{format("en-GB").RelativeTo(tm, tm.Add(time.Second)), "just now"},
+		{format("de-DE").RelativeTo(tm, tm.Add(49*time.Hour)), "vor 2 Tagen"},
+		{format("fr-FR").RelativeTo(tm, tm.Add(-400*24*time.Hour)), "dans 1 an"},
+		{format("en-GB").Number(-1234567.891, 2), "-1,234,567.89"},
+		{format("en-GB").Number(-0.001, 2), "0.00"},
+		{format("de-DE").Number(1234567.891, 1), "1.234.567,9"},
+		{format("fr-FR").Int(1234567), "1 234 567"},
+		{format("en-GB").Percent(0.256, 1), "25.6%"},
+		{format("fr-FR").Percent(-0.5, 0), "-50 %"},
+		{format("en-GB").Currency(1234.5, "GBP"), "£1,234.50"},
+		{format("en-US").Currency(-1234.6, "jpy"), "-¥1,235"},
+		{format("de-DE").Currency(1234.5, "EUR"), "1.234,50 €"},
+		{format("en-GB").Currency(1, "XYZ"), "XYZ1.00"},
+	} {
+		if test.result != test.expected {
+			t.Fail()
+		}
+	}
+}
+
+func TestFormatLayoutNoNames(t *testing.T) {
+	App := NewApp()
+	App.LangCode = NewAtomicString("xx")
+
+	c := &Context{App: App}
+	c.Pub.LangCode = "xx"
+
+	// No month or day names, Go's English names are kept
+	tm := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	if out := c.Format().Layout(tm, "Monday 2 January, Mon Jan"); out != "Sunday 1 January, Sun Jan" {
+		t.Errorf("%q", out)
+	}
+}
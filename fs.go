@@ -0,0 +1,128 @@
+package core
+
+import (
+	"html/template"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Disk directory overriding fsys while App.Debug is on.
+type overlayFS struct {
+	app  *App
+	base fs.FS
+	dir  fs.FS
+}
+
+func (o overlayFS) Open(name string) (fs.File, error) {
+	if o.app.Debug {
+		if file, err := o.dir.Open(name); err == nil {
+			return file, nil
+		}
+	}
+	return o.base.Open(name)
+}
+
+// Implement fs.StatFS
+func (o overlayFS) Stat(name string) (fs.FileInfo, error) {
+	if o.app.Debug {
+		if info, err := fs.Stat(o.dir, name); err == nil {
+			return info, nil
+		}
+	}
+	return fs.Stat(o.base, name)
+}
+
+// Implement fs.ReadDirFS, entries of both layers are merged while Debug is on, dir wins on equal names.
+func (o overlayFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	entries, err := fs.ReadDir(o.base, name)
+	if !o.app.Debug {
+		return entries, err
+	}
+
+	disk, diskErr := fs.ReadDir(o.dir, name)
+	if diskErr != nil {
+		return entries, err
+	}
+	if err != nil {
+		return disk, nil
+	}
+
+	merged := map[string]fs.DirEntry{}
+	for _, entry := range entries {
+		merged[entry.Name()] = entry
+	}
+	for _, entry := range disk {
+		merged[entry.Name()] = entry
+	}
+
+	out := make([]fs.DirEntry, 0, len(merged))
+	for _, entry := range merged {
+		out = append(out, entry)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].Name() < out[j].Name()
+	})
+	return out, nil
+}
+
+// File System where dir overrides fsys while Debug is on, e.g. edit embedded templates without rebuilding.
+// Checked on every Open, so Debug may be toggled after.
+func (app *App) OverlayFS(fsys fs.FS, dir string) fs.FS {
+	return overlayFS{app, fsys, os.DirFS(dir)}
+}
+
+type htmlTemplateCache struct {
+	tmpl    *template.Template
+	expires time.Time
+}
+
+// Parse templates matching patterns from HtmlFS (working directory if nil) with HtmlFuncMap.
+// Templates are named by base name, the first file is the root template.
+// Cached for HtmlTemplateCacheExpire, parsed on every call in Debug.
+func (app *App) HtmlTemplate(patterns ...string) (*template.Template, error) {
+	key := strings.Join(patterns, "\n")
+
+	if !app.Debug {
+		app.htmlFileCacheSync.Lock()
+		cached, ok := app.htmlFileCache[key].(htmlTemplateCache)
+		app.htmlFileCacheSync.Unlock()
+		if ok && time.Now().Before(cached.expires) {
+			return cached.tmpl, nil
+		}
+	}
+
+	fsys := app.HtmlFS
+	if fsys == nil {
+		fsys = os.DirFS(".")
+	}
+
+	files := []string{}
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, ErrorStr("Template pattern matches no files: " + pattern)
+		}
+		files = append(files, matches...)
+	}
+	if len(files) == 0 {
+		return nil, ErrorStr("No template patterns")
+	}
+
+	tmpl, err := template.New(path.Base(files[0])).Funcs(app.HtmlFuncMap).ParseFS(fsys, files...)
+	if err != nil {
+		return nil, err
+	}
+
+	app.htmlFileCacheSync.Lock()
+	app.htmlFileCache[key] = htmlTemplateCache{tmpl, time.Now().Add(app.HtmlTemplateCacheExpire)}
+	app.htmlFileCacheSync.Unlock()
+
+	return tmpl, nil
+}
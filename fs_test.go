@@ -0,0 +1,104 @@
+package core
+
+import (
+	"bytes"
+	"io/fs"
+	"io/ioutil"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestStaticFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"app.js":     {Data: []byte("embedded")},
+		"index.html": {Data: []byte("<h1>home</h1>")},
+	}
+
+	App := NewApp()
+	App.StaticFS("/static/", fsys)
+	App.DefaultRouter = NewDirRouter().Register("files", FileServerFS(fsys))
+
+	for _, path := range []string{"/static/app.js", "/files/app.js"} {
+		res := httptest.NewRecorder()
+		App.mux.ServeHTTP(res, httptest.NewRequest("GET", path, nil))
+		if res.Code != 200 || res.Body.String() != "embedded" {
+			t.Fail()
+		}
+	}
+
+	res := httptest.NewRecorder()
+	App.mux.ServeHTTP(res, httptest.NewRequest("GET", "/static/", nil))
+	if res.Body.String() != "<h1>home</h1>" {
+		t.Fail()
+	}
+}
+
+func TestOverlayFS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "core-overlay")
+	Check(err)
+	defer os.RemoveAll(dir)
+	Check(ioutil.WriteFile(filepath.Join(dir, "page.html"), []byte("disk {{.}}"), 0644))
+
+	App := NewApp()
+	App.HtmlFS = App.OverlayFS(fstest.MapFS{
+		"page.html":   {Data: []byte(`embedded {{.}} {{template "footer.html"}}`)},
+		"footer.html": {Data: []byte(`{{upper "footer"}}`)},
+	}, dir)
+	App.HtmlFuncMap = map[string]interface{}{"upper": strings.ToUpper}
+
+	execute := func() string {
+		tmpl, err := App.HtmlTemplate("page.html", "footer.html")
+		if err != nil {
+			t.Fatal(err)
+		}
+		buf := &bytes.Buffer{}
+		Check(tmpl.Execute(buf, "page"))
+		return buf.String()
+	}
+
+	if execute() != "embedded page FOOTER" {
+		t.Fail()
+	}
+
+	App.Debug = true
+	if execute() != "disk page" {
+		t.Fail()
+	}
+
+	if _, err := App.HtmlTemplate("missing/*.html"); err == nil {
+		t.Fail()
+	}
+}
+
+func TestOverlayFSGlob(t *testing.T) {
+	dir, err := ioutil.TempDir("", "core-overlay")
+	Check(err)
+	defer os.RemoveAll(dir)
+	Check(os.Mkdir(filepath.Join(dir, "views"), 0755))
+	Check(ioutil.WriteFile(filepath.Join(dir, "views", "a.html"), []byte(`disk A {{template "b.html"}}`), 0644))
+
+	App := NewApp()
+	App.Debug = true
+	App.HtmlFS = App.OverlayFS(fstest.MapFS{
+		"views/a.html": {Data: []byte(`embedded A {{template "b.html"}}`)},
+		"views/b.html": {Data: []byte(`B`)},
+	}, dir)
+
+	// Disk only overrides a.html, b.html stays visible from the embedded layer
+	tmpl, err := App.HtmlTemplate("views/*.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := &bytes.Buffer{}
+	if tmpl.Execute(buf, nil) != nil || buf.String() != "disk A B" {
+		t.Errorf("%q", buf.String())
+	}
+
+	if info, err := fs.Stat(App.HtmlFS, "views/b.html"); err != nil || info.Size() != 1 {
+		t.Fail()
+	}
+}
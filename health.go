@@ -0,0 +1,124 @@
+package core
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+)
+
+// Health Check, return nil if healthy.
+type HealthCheck func() error
+
+// Health and Readiness Checks, Healthz and Readyz implement RouteHandler.
+type Health struct {
+	sync.RWMutex
+	live     map[string]HealthCheck
+	ready    map[string]HealthCheck
+	notReady bool
+}
+
+// Construct New Health
+func NewHealth() *Health {
+	return &Health{live: map[string]HealthCheck{}, ready: map[string]HealthCheck{}}
+}
+
+// Register Liveness Check, also used by Readyz.
+func (h *Health) Live(name string, check HealthCheck) *Health {
+	h.Lock()
+	defer h.Unlock()
+	h.live[name] = check
+	return h
+}
+
+// Register Readiness Check
+func (h *Health) Ready(name string, check HealthCheck) *Health {
+	h.Lock()
+	defer h.Unlock()
+	h.ready[name] = check
+	return h
+}
+
+// Set Readiness, set to false before shutting down to drain traffic from load balancer.
+func (h *Health) SetReady(ready bool) *Health {
+	h.Lock()
+	defer h.Unlock()
+	h.notReady = !ready
+	return h
+}
+
+func (h *Health) run(c *Context, readiness bool) {
+	h.RLock()
+	checks := map[string]HealthCheck{}
+	for name, check := range h.live {
+		checks[name] = check
+	}
+	if readiness {
+		for name, check := range h.ready {
+			checks[name] = check
+		}
+	}
+	notReady := h.notReady && readiness
+	h.RUnlock()
+
+	names := []string{}
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	c.Pub.Status = http.StatusOK
+	out := []string{}
+
+	if notReady {
+		c.Pub.Status = http.StatusServiceUnavailable
+		out = append(out, "fail ready: shutting down")
+	}
+
+	for _, name := range names {
+		if err := checks[name](); err != nil {
+			c.Pub.Status = http.StatusServiceUnavailable
+			out = append(out, "fail "+name+": "+err.Error())
+			continue
+		}
+		out = append(out, "ok "+name)
+	}
+
+	c.Res.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	c.Res.Header().Set("Cache-Control", "no-store")
+
+	if c.Pub.Status == http.StatusOK {
+		out = append(out, "ok")
+	} else {
+		out = append(out, "fail")
+	}
+
+	for _, line := range out {
+		c.Fmt().Print(line, "\n")
+	}
+}
+
+// Liveness Handler, e.g. /healthz
+func (h *Health) Healthz() RouteHandler {
+	return RouteHandlerFunc(func(c *Context) {
+		h.run(c, false)
+	})
+}
+
+// Readiness Handler, e.g. /readyz
+func (h *Health) Readyz() RouteHandler {
+	return RouteHandlerFunc(func(c *Context) {
+		h.run(c, true)
+	})
+}
+
+// Register 'healthz' and 'readyz' to Directory Router
+func (h *Health) Register(dir *DirRouter) *Health {
+	dir.Register("healthz", h.Healthz())
+	dir.Register("readyz", h.Readyz())
+	return h
+}
+
+// Get Health Checks
+func (app *App) Health() *Health {
+	return app.health
+}
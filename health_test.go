@@ -0,0 +1,59 @@
+package core
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHealth(t *testing.T) {
+	App := NewApp()
+
+	dbErr := error(nil)
+
+	App.Health().Live("app", func() error {
+		return nil
+	}).Ready("db", func() error {
+		return dbErr
+	}).Register(App.DirRouter("main"))
+
+	App.DefaultRouter = App.DirRouter("main")
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	get := func(path string) (int, string) {
+		res, err := http.Get(ts.URL + path)
+		Check(err)
+		b, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		return res.StatusCode, string(b)
+	}
+
+	if status, body := get("/healthz"); status != 200 || body != "ok app\nok\n" {
+		t.Error(status, body)
+	}
+
+	if status, body := get("/readyz"); status != 200 || body != "ok app\nok db\nok\n" {
+		t.Error(status, body)
+	}
+
+	dbErr = errors.New("down")
+
+	if status, body := get("/readyz"); status != 503 || body != "ok app\nfail db: down\nfail\n" {
+		t.Error(status, body)
+	}
+
+	if status, _ := get("/healthz"); status != 200 {
+		t.Error(status)
+	}
+
+	dbErr = nil
+	App.Health().SetReady(false)
+
+	if status, _ := get("/readyz"); status != 503 {
+		t.Error(status)
+	}
+}
@@ -0,0 +1,128 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// Typed HTTP Error, Implement error interface.
+type HTTPError struct {
+	// Status Code
+	Status int
+	// Public Message, safe to show to the client
+	Message string
+	// Internal Cause, logged but never shown to the client
+	Cause error
+	// Details, e.g. validation failures by field name
+	Details map[string]interface{}
+}
+
+// Construct New HTTPError
+func NewHTTPError(status int, message string) *HTTPError {
+	return &HTTPError{Status: status, Message: message}
+}
+
+// Construct New HTTPError with formatted message
+func HTTPErrorf(status int, format string, a ...interface{}) *HTTPError {
+	return NewHTTPError(status, fmt.Sprintf(format, a...))
+}
+
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprint(e.Status, " ", e.Message)
+	if e.Cause != nil {
+		msg += ": " + e.Cause.Error()
+	}
+	return msg
+}
+
+// Copy with Details map of its own, shared errors (e.g. ErrMethodNotAllowed) are never modified.
+func (e *HTTPError) copy() *HTTPError {
+	out := *e
+	if e.Details != nil {
+		out.Details = make(map[string]interface{}, len(e.Details)+1)
+		for key, value := range e.Details {
+			out.Details[key] = value
+		}
+	}
+	return &out
+}
+
+// Copy with Internal Cause
+func (e *HTTPError) WithCause(err error) *HTTPError {
+	out := e.copy()
+	out.Cause = err
+	return out
+}
+
+// Copy with Detail added
+func (e *HTTPError) WithDetail(key string, value interface{}) *HTTPError {
+	out := e.copy()
+	if out.Details == nil {
+		out.Details = map[string]interface{}{}
+	}
+	out.Details[key] = value
+	return out
+}
+
+// Shortcut to NewHTTPError(400, message)
+func ErrBadRequest(message string) *HTTPError {
+	return NewHTTPError(400, message)
+}
+
+// Shortcut to NewHTTPError(401, message)
+func ErrUnauthorised(message string) *HTTPError {
+	return NewHTTPError(401, message)
+}
+
+// Shortcut to NewHTTPError(403, message)
+func ErrForbidden(message string) *HTTPError {
+	return NewHTTPError(403, message)
+}
+
+// Shortcut to NewHTTPError(404, message)
+func ErrNotFound(message string) *HTTPError {
+	return NewHTTPError(404, message)
+}
+
+// Shortcut to NewHTTPError(409, message)
+func ErrConflict(message string) *HTTPError {
+	return NewHTTPError(409, message)
+}
+
+// Shortcut to NewHTTPError(422, message)
+func ErrUnprocessable(message string) *HTTPError {
+	return NewHTTPError(422, message)
+}
+
+// Shortcut to NewHTTPError(500, message) with internal cause
+func ErrInternal(cause error) *HTTPError {
+	return NewHTTPError(500, "").WithCause(cause)
+}
+
+// Returned by default MethodErr verbs.
+var ErrMethodNotAllowed = NewHTTPError(405, "")
+
+// Map error to error registry, internal causes and untyped errors are sent to PanicHandler.
+// Does nothing if err is nil.
+func (c *Context) HandleError(err error) {
+	if err == nil {
+		return
+	}
+
+	var httpErr *HTTPError
+	if !errors.As(err, &httpErr) {
+		httpErr = ErrInternal(err)
+	}
+
+	if httpErr.Cause != nil {
+		c.App.panicHandler().Panic(c, httpErr.Cause, debug.Stack())
+	}
+
+	status := httpErr.Status
+	if status == 0 {
+		status = 500
+	}
+
+	c.Error(status, httpErr)
+}
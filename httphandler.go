@@ -1,15 +1,63 @@
-package core
-
-import (
-	"net/http"
-)
-
-// 'net/http.Handler' Adapter.  Implement RouterHandler interface
-type HttpRouteHandler struct {
-	http.Handler
-}
-
-// Implement RouteHandler
-func (ht HttpRouteHandler) View(c *Context) {
-	ht.ServeHTTP(c.Res, c.Req)
-}
+package core
+
+import (
+	"io"
+	"net/http"
+)
+
+// 'net/http.Handler' Adapter.  Implement RouterHandler interface
+type HttpRouteHandler struct {
+	http.Handler
+}
+
+// Implement RouteHandler
+func (ht HttpRouteHandler) View(c *Context) {
+	ht.ServeHTTP(c.Res, c.Req)
+}
+
+// Adapt 'net/http' Middleware to MiddlewareFunc, the rest of the chain is wrapped as http.Handler.
+// Context passes through, the ResponseWriter and Request given to next are used until it returns.
+func HttpMiddleware(mw func(http.Handler) http.Handler) MiddlewareFunc {
+	return func(c *Context, next func()) {
+		handler := mw(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			restore := c.swapResponseWriter(w, req)
+			defer restore()
+			next()
+		}))
+		handler.ServeHTTP(resWriter{c.Res, c.pri.reswrite}, c.Req)
+	}
+}
+
+// Res with fixed underlying writer, so swapping reswrite does not loop back.
+type resWriter struct {
+	Res
+	w io.Writer
+}
+
+func (r resWriter) Write(data []byte) (int, error) {
+	return r.Res.write(r.w, data)
+}
+
+// Swap Response Writer and Request, until restore is called.
+func (c *Context) swapResponseWriter(w http.ResponseWriter, req *http.Request) (restore func()) {
+	res, reswrite, oldReq := c.Res, c.pri.reswrite, c.Req
+
+	c.Res = Res{w, c}
+	if req.Method != "HEAD" {
+		c.pri.reswrite = w
+	}
+	c.Req = req
+
+	return func() {
+		c.Res, c.pri.reswrite, c.Req = res, reswrite, oldReq
+	}
+}
+
+// Export Route Handler as 'net/http.Handler', served with the full Context life cycle
+// (session, "main" middleware, error handling) in place of DefaultView.
+// Use http.StripPrefix when mounted under a sub path.
+func (app *App) HttpHandler(route RouteHandler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		app.serveRoute(res, req, req.TLS != nil, route)
+	})
+}
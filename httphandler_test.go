@@ -0,0 +1,94 @@
+package core
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type upperWriter struct {
+	http.ResponseWriter
+}
+
+func (w upperWriter) Write(b []byte) (int, error) {
+	return w.ResponseWriter.Write(bytes.ToUpper(b))
+}
+
+func TestHttpMiddleware(t *testing.T) {
+	App := NewApp()
+
+	std := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Header().Set("X-Std", "yes")
+			next.ServeHTTP(upperWriter{w}, req)
+		})
+	}
+
+	deny := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			http.Error(w, "denied", 401)
+		})
+	}
+
+	App.Use(func(c *Context, next func()) {
+		c.Pub.Group.Set("user", "gorail")
+		next()
+	})
+
+	App.DefaultRouter = NewDirRouter().RootDir(WithMiddleware(RouteHandlerFunc(func(c *Context) {
+		c.Fmt().Print("hello ", c.Pub.Group.Get("user"))
+	}), HttpMiddleware(std))).Register("deny", WithMiddleware(RouteHandlerFunc(func(c *Context) {
+		t.Fail()
+	}), HttpMiddleware(deny)))
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL)
+	Check(err)
+	b, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	if string(b) != "HELLO GORAIL" || res.Header.Get("X-Std") != "yes" {
+		t.Fail()
+	}
+
+	res, err = http.Get(ts.URL + "/deny")
+	Check(err)
+	res.Body.Close()
+
+	if res.StatusCode != 401 {
+		t.Fail()
+	}
+}
+
+func TestAppHttpHandler(t *testing.T) {
+	App := NewApp()
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/", http.StripPrefix("/api", App.HttpHandler(NewDirRouter().Register("ping", RouteHandlerFunc(func(c *Context) {
+		c.Fmt().Print("pong")
+	})))))
+
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "/api/ping")
+	Check(err)
+	b, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	if string(b) != "pong" {
+		t.Fail()
+	}
+
+	res, err = http.Get(ts.URL + "/api/none")
+	Check(err)
+	res.Body.Close()
+
+	if res.StatusCode != 404 {
+		t.Fail()
+	}
+}
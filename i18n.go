@@ -1,153 +1,330 @@
-package core
-
-import (
-	"sync"
-)
-
-type LangPackage struct {
-	langPackage *langPackage
-	fallback    *langPackage
-}
-
-type langPackage struct {
-	_m sync.RWMutex
-	m  map[string]string
-}
-
-func (l *LangPackage) Key(name string) string {
-	if l.langPackage != nil {
-		l.langPackage._m.RLock()
-		defer l.langPackage._m.RUnlock()
-		if l.langPackage.m[name] != "" {
-			return l.langPackage.m[name]
-		}
-	}
-	if l.fallback != nil {
-		l.fallback._m.RLock()
-		defer l.fallback._m.RUnlock()
-		return l.fallback.m[name]
-	}
-	return ""
-}
-
-type Lang struct {
-	lang     *lang
-	fallback *lang
-}
-
-type lang struct {
-	_m sync.RWMutex
-	m  map[string]*langPackage
-}
-
-func (l *Lang) Package(name string) *LangPackage {
-	var lang, fallback *langPackage
-	if l.lang != nil {
-		l.lang._m.RLock()
-		defer l.lang._m.RUnlock()
-		if l.lang.m[name] != nil {
-			lang = l.lang.m[name]
-		}
-	}
-	if l.fallback != nil {
-		l.fallback._m.RLock()
-		defer l.fallback._m.RUnlock()
-
-		if l.fallback.m[name] != nil {
-			fallback = l.fallback.m[name]
-		}
-	}
-	return &LangPackage{lang, fallback}
-}
-
-func (l *Lang) Key(name string) string {
-	return l.Package("core").Key(name)
-}
-
-type _langs struct {
-	_m sync.RWMutex
-	m  map[string]*lang
-}
-
-var langs = &_langs{m: map[string]*lang{}}
-
-func (c *Context) Lang() *Lang {
-	langs._m.RLock()
-	defer langs._m.RUnlock()
-	return &Lang{langs.m[c.Pub.LangCode], langs.m[c.App.LangCode.String()]}
-}
-
-func LangKeyValueRegister(langCode, _package string, keyValue map[string]string) {
-	langs._m.Lock()
-	defer langs._m.Unlock()
-	if langs.m[langCode] == nil {
-		langs.m[langCode] = &lang{m: map[string]*langPackage{_package: &langPackage{m: keyValue}}}
-		return
-	}
-	langs.m[langCode]._m.Lock()
-	defer langs.m[langCode]._m.Unlock()
-	if langs.m[langCode].m[_package] == nil {
-		langs.m[langCode].m[_package] = &langPackage{m: keyValue}
-		return
-	}
-	pack := langs.m[langCode].m[_package]
-	pack._m.Lock()
-	defer pack._m.Unlock()
-	for key, value := range keyValue {
-		pack.m[key] = value
-	}
-}
-
-func LangAlias(aliasName, of string) {
-	langs._m.Lock()
-	defer langs._m.Unlock()
-	if langs.m[of] == nil {
-		return
-	}
-	langs.m[aliasName] = langs.m[of]
-}
-
-func init() {
-	p := "core"
-
-	// British English
-	LangKeyValueRegister("en-GB", p, map[string]string{
-		"dir":                  "ltr",
-		"init":                 "initialise",
-		"timeFormat":           "Monday, _2 January 2006, 15:04",
-		"shortTimeFormat":      "_2/01/2006 15:04",
-		"dateFormat":           "Monday, _2 January 2006",
-		"shortDateFormat":      "_2/01/2006",
-		"kitchenTimeFormat":    "15:04",
-		"timeZoneFormat":       "MST",
-		"errNoOutput":          "No output was sent to Client!",
-		"err403":               "403 Forbidden",
-		"err404":               "404 Not Found",
-		"err405":               "405 Method Not Allowed",
-		"err500":               "500 Internal Server Error",
-		"errCookieNameCheck":   "Cookie name check failed",
-		"errHmacDataIntegrity": "Data has been tampered with!",
-	})
-
-	// American English
-	LangKeyValueRegister("en-US", p, map[string]string{
-		"dir":                  "ltr",
-		"init":                 "initialize",
-		"timeFormat":           "Monday, January _2 2006, 3:04PM",
-		"shortTimeFormat":      "01/_2/2006 3:04PM",
-		"dateFormat":           "Monday, January _2 2006",
-		"shortDateFormat":      "01/_2/2006",
-		"kitchenTimeFormat":    "3:04PM",
-		"timeZoneFormat":       "MST",
-		"errNoOutput":          "No output was sent to Client!",
-		"err403":               "403 Forbidden",
-		"err404":               "404 Not Found",
-		"err405":               "405 Method Not Allowed",
-		"err500":               "500 Internal Server Error",
-		"errCookieNameCheck":   "Cookie name check failed",
-		"errHmacDataIntegrity": "Data has been tampered with!",
-	})
-
-	// Sadly for the British, 'en' happens to be the short version of 'en-US'
-	LangAlias("en", "en-US")
-}
+package core
+
+import (
+	"sync"
+)
+
+type LangPackage struct {
+	langPackage *langPackage
+	fallback    *langPackage
+}
+
+type langPackage struct {
+	_m   sync.RWMutex
+	m    map[string]string
+	code string
+}
+
+func (l *LangPackage) Key(name string) string {
+	if l.langPackage != nil {
+		l.langPackage._m.RLock()
+		defer l.langPackage._m.RUnlock()
+		if l.langPackage.m[name] != "" {
+			return l.langPackage.m[name]
+		}
+	}
+	if l.fallback != nil {
+		l.fallback._m.RLock()
+		defer l.fallback._m.RUnlock()
+		return l.fallback.m[name]
+	}
+	return ""
+}
+
+type Lang struct {
+	lang     *lang
+	fallback *lang
+}
+
+type lang struct {
+	_m sync.RWMutex
+	m  map[string]*langPackage
+}
+
+func (l *Lang) Package(name string) *LangPackage {
+	var lang, fallback *langPackage
+	if l.lang != nil {
+		l.lang._m.RLock()
+		defer l.lang._m.RUnlock()
+		if l.lang.m[name] != nil {
+			lang = l.lang.m[name]
+		}
+	}
+	if l.fallback != nil {
+		l.fallback._m.RLock()
+		defer l.fallback._m.RUnlock()
+
+		if l.fallback.m[name] != nil {
+			fallback = l.fallback.m[name]
+		}
+	}
+	return &LangPackage{lang, fallback}
+}
+
+func (l *Lang) Key(name string) string {
+	return l.Package("core").Key(name)
+}
+
+type _langs struct {
+	_m sync.RWMutex
+	m  map[string]*lang
+}
+
+var langs = &_langs{m: map[string]*lang{}}
+
+func (c *Context) Lang() *Lang {
+	langs._m.RLock()
+	defer langs._m.RUnlock()
+	// en-AU falls back to en-GB, then en, see LocaleParents
+	for code := c.Pub.LangCode; code != ""; code = LocaleParent(code) {
+		if langs.m[code] != nil {
+			return &Lang{langs.m[code], langs.m[c.App.LangCode.String()]}
+		}
+	}
+	return &Lang{nil, langs.m[c.App.LangCode.String()]}
+}
+
+func LangKeyValueRegister(langCode, _package string, keyValue map[string]string) {
+	langs._m.Lock()
+	defer langs._m.Unlock()
+	if langs.m[langCode] == nil {
+		langs.m[langCode] = &lang{m: map[string]*langPackage{_package: &langPackage{m: keyValue, code: langCode}}}
+		return
+	}
+	langs.m[langCode]._m.Lock()
+	defer langs.m[langCode]._m.Unlock()
+	if langs.m[langCode].m[_package] == nil {
+		langs.m[langCode].m[_package] = &langPackage{m: keyValue, code: langCode}
+		return
+	}
+	pack := langs.m[langCode].m[_package]
+	pack._m.Lock()
+	defer pack._m.Unlock()
+	for key, value := range keyValue {
+		pack.m[key] = value
+	}
+}
+
+func LangAlias(aliasName, of string) {
+	langs._m.Lock()
+	defer langs._m.Unlock()
+	if langs.m[of] == nil {
+		return
+	}
+	langs.m[aliasName] = langs.m[of]
+}
+
+func init() {
+	p := "core"
+
+	// British English
+	LangKeyValueRegister("en-GB", p, map[string]string{
+		"dir":                  "ltr",
+		"init":                 "initialise",
+		"timeFormat":           "Monday, _2 January 2006, 15:04",
+		"shortTimeFormat":      "_2/01/2006 15:04",
+		"dateFormat":           "Monday, _2 January 2006",
+		"shortDateFormat":      "_2/01/2006",
+		"kitchenTimeFormat":    "15:04",
+		"timeZoneFormat":       "MST",
+		"errNoOutput":          "No output was sent to Client!",
+		"err400":               "400 Bad Request",
+		"err401":               "401 Unauthorised",
+		"err403":               "403 Forbidden",
+		"err404":               "404 Not Found",
+		"err405":               "405 Method Not Allowed",
+		"err409":               "409 Conflict",
+		"err410":               "410 Gone",
+		"err413":               "413 Request Entity Too Large",
+		"err422":               "422 Unprocessable Entity",
+		"err429":               "429 Too Many Requests",
+		"err500":               "500 Internal Server Error",
+		"err503":               "503 Service Unavailable",
+		"errCookieNameCheck":   "Cookie name check failed",
+		"errHmacDataIntegrity": "Data has been tampered with!",
+		"numberDecimal":        ".",
+		"numberGroup":          ",",
+		"percentFormat":        "{value}%",
+		"currencyFormat":       "{symbol}{value}",
+		"months":               "January,February,March,April,May,June,July,August,September,October,November,December",
+		"monthsShort":          "Jan,Feb,Mar,Apr,May,Jun,Jul,Aug,Sep,Oct,Nov,Dec",
+		"days":                 "Sunday,Monday,Tuesday,Wednesday,Thursday,Friday,Saturday",
+		"daysShort":            "Sun,Mon,Tue,Wed,Thu,Fri,Sat",
+		"relNow":               "just now",
+		"relSecondAgo.one":     "{count} second ago",
+		"relSecondAgo.other":   "{count} seconds ago",
+		"relSecondIn.one":      "in {count} second",
+		"relSecondIn.other":    "in {count} seconds",
+		"relMinuteAgo.one":     "{count} minute ago",
+		"relMinuteAgo.other":   "{count} minutes ago",
+		"relMinuteIn.one":      "in {count} minute",
+		"relMinuteIn.other":    "in {count} minutes",
+		"relHourAgo.one":       "{count} hour ago",
+		"relHourAgo.other":     "{count} hours ago",
+		"relHourIn.one":        "in {count} hour",
+		"relHourIn.other":      "in {count} hours",
+		"relDayAgo.one":        "{count} day ago",
+		"relDayAgo.other":      "{count} days ago",
+		"relDayIn.one":         "in {count} day",
+		"relDayIn.other":       "in {count} days",
+		"relMonthAgo.one":      "{count} month ago",
+		"relMonthAgo.other":    "{count} months ago",
+		"relMonthIn.one":       "in {count} month",
+		"relMonthIn.other":     "in {count} months",
+		"relYearAgo.one":       "{count} year ago",
+		"relYearAgo.other":     "{count} years ago",
+		"relYearIn.one":        "in {count} year",
+		"relYearIn.other":      "in {count} years",
+	})
+
+	// American English
+	LangKeyValueRegister("en-US", p, map[string]string{
+		"dir":                  "ltr",
+		"init":                 "initialize",
+		"timeFormat":           "Monday, January _2 2006, 3:04PM",
+		"shortTimeFormat":      "01/_2/2006 3:04PM",
+		"dateFormat":           "Monday, January _2 2006",
+		"shortDateFormat":      "01/_2/2006",
+		"kitchenTimeFormat":    "3:04PM",
+		"timeZoneFormat":       "MST",
+		"errNoOutput":          "No output was sent to Client!",
+		"err400":               "400 Bad Request",
+		"err401":               "401 Unauthorized",
+		"err403":               "403 Forbidden",
+		"err404":               "404 Not Found",
+		"err405":               "405 Method Not Allowed",
+		"err409":               "409 Conflict",
+		"err410":               "410 Gone",
+		"err413":               "413 Request Entity Too Large",
+		"err422":               "422 Unprocessable Entity",
+		"err429":               "429 Too Many Requests",
+		"err500":               "500 Internal Server Error",
+		"err503":               "503 Service Unavailable",
+		"errCookieNameCheck":   "Cookie name check failed",
+		"errHmacDataIntegrity": "Data has been tampered with!",
+		"numberDecimal":        ".",
+		"numberGroup":          ",",
+		"percentFormat":        "{value}%",
+		"currencyFormat":       "{symbol}{value}",
+		"months":               "January,February,March,April,May,June,July,August,September,October,November,December",
+		"monthsShort":          "Jan,Feb,Mar,Apr,May,Jun,Jul,Aug,Sep,Oct,Nov,Dec",
+		"days":                 "Sunday,Monday,Tuesday,Wednesday,Thursday,Friday,Saturday",
+		"daysShort":            "Sun,Mon,Tue,Wed,Thu,Fri,Sat",
+		"relNow":               "just now",
+		"relSecondAgo.one":     "{count} second ago",
+		"relSecondAgo.other":   "{count} seconds ago",
+		"relSecondIn.one":      "in {count} second",
+		"relSecondIn.other":    "in {count} seconds",
+		"relMinuteAgo.one":     "{count} minute ago",
+		"relMinuteAgo.other":   "{count} minutes ago",
+		"relMinuteIn.one":      "in {count} minute",
+		"relMinuteIn.other":    "in {count} minutes",
+		"relHourAgo.one":       "{count} hour ago",
+		"relHourAgo.other":     "{count} hours ago",
+		"relHourIn.one":        "in {count} hour",
+		"relHourIn.other":      "in {count} hours",
+		"relDayAgo.one":        "{count} day ago",
+		"relDayAgo.other":      "{count} days ago",
+		"relDayIn.one":         "in {count} day",
+		"relDayIn.other":       "in {count} days",
+		"relMonthAgo.one":      "{count} month ago",
+		"relMonthAgo.other":    "{count} months ago",
+		"relMonthIn.one":       "in {count} month",
+		"relMonthIn.other":     "in {count} months",
+		"relYearAgo.one":       "{count} year ago",
+		"relYearAgo.other":     "{count} years ago",
+		"relYearIn.one":        "in {count} year",
+		"relYearIn.other":      "in {count} years",
+	})
+
+	// French, formatting only, other keys fall back to App.LangCode
+	LangKeyValueRegister("fr-FR", p, map[string]string{
+		"dir":                "ltr",
+		"timeFormat":         "Monday _2 January 2006 15:04",
+		"shortTimeFormat":    "02/01/2006 15:04",
+		"dateFormat":         "Monday _2 January 2006",
+		"shortDateFormat":    "02/01/2006",
+		"kitchenTimeFormat":  "15:04",
+		"timeZoneFormat":     "MST",
+		"numberDecimal":      ",",
+		"numberGroup":        "\u202f",
+		"percentFormat":      "{value}\u00a0%",
+		"currencyFormat":     "{value}\u00a0{symbol}",
+		"months":             "janvier,février,mars,avril,mai,juin,juillet,août,septembre,octobre,novembre,décembre",
+		"monthsShort":        "janv.,févr.,mars,avr.,mai,juin,juil.,août,sept.,oct.,nov.,déc.",
+		"days":               "dimanche,lundi,mardi,mercredi,jeudi,vendredi,samedi",
+		"daysShort":          "dim.,lun.,mar.,mer.,jeu.,ven.,sam.",
+		"relNow":             "à l’instant",
+		"relSecondAgo.one":   "il y a {count} seconde",
+		"relSecondAgo.other": "il y a {count} secondes",
+		"relSecondIn.one":    "dans {count} seconde",
+		"relSecondIn.other":  "dans {count} secondes",
+		"relMinuteAgo.one":   "il y a {count} minute",
+		"relMinuteAgo.other": "il y a {count} minutes",
+		"relMinuteIn.one":    "dans {count} minute",
+		"relMinuteIn.other":  "dans {count} minutes",
+		"relHourAgo.one":     "il y a {count} heure",
+		"relHourAgo.other":   "il y a {count} heures",
+		"relHourIn.one":      "dans {count} heure",
+		"relHourIn.other":    "dans {count} heures",
+		"relDayAgo.one":      "il y a {count} jour",
+		"relDayAgo.other":    "il y a {count} jours",
+		"relDayIn.one":       "dans {count} jour",
+		"relDayIn.other":     "dans {count} jours",
+		"relMonthAgo.one":    "il y a {count} mois",
+		"relMonthAgo.other":  "il y a {count} mois",
+		"relMonthIn.one":     "dans {count} mois",
+		"relMonthIn.other":   "dans {count} mois",
+		"relYearAgo.one":     "il y a {count} an",
+		"relYearAgo.other":   "il y a {count} ans",
+		"relYearIn.one":      "dans {count} an",
+		"relYearIn.other":    "dans {count} ans",
+	})
+
+	// German, formatting only, other keys fall back to App.LangCode
+	LangKeyValueRegister("de-DE", p, map[string]string{
+		"dir":                "ltr",
+		"timeFormat":         "Monday, _2. January 2006, 15:04",
+		"shortTimeFormat":    "02.01.2006 15:04",
+		"dateFormat":         "Monday, _2. January 2006",
+		"shortDateFormat":    "02.01.2006",
+		"kitchenTimeFormat":  "15:04",
+		"timeZoneFormat":     "MST",
+		"numberDecimal":      ",",
+		"numberGroup":        ".",
+		"percentFormat":      "{value}\u00a0%",
+		"currencyFormat":     "{value}\u00a0{symbol}",
+		"months":             "Januar,Februar,März,April,Mai,Juni,Juli,August,September,Oktober,November,Dezember",
+		"monthsShort":        "Jan.,Feb.,März,Apr.,Mai,Juni,Juli,Aug.,Sept.,Okt.,Nov.,Dez.",
+		"days":               "Sonntag,Montag,Dienstag,Mittwoch,Donnerstag,Freitag,Samstag",
+		"daysShort":          "So.,Mo.,Di.,Mi.,Do.,Fr.,Sa.",
+		"relNow":             "gerade eben",
+		"relSecondAgo.one":   "vor {count} Sekunde",
+		"relSecondAgo.other": "vor {count} Sekunden",
+		"relSecondIn.one":    "in {count} Sekunde",
+		"relSecondIn.other":  "in {count} Sekunden",
+		"relMinuteAgo.one":   "vor {count} Minute",
+		"relMinuteAgo.other": "vor {count} Minuten",
+		"relMinuteIn.one":    "in {count} Minute",
+		"relMinuteIn.other":  "in {count} Minuten",
+		"relHourAgo.one":     "vor {count} Stunde",
+		"relHourAgo.other":   "vor {count} Stunden",
+		"relHourIn.one":      "in {count} Stunde",
+		"relHourIn.other":    "in {count} Stunden",
+		"relDayAgo.one":      "vor {count} Tag",
+		"relDayAgo.other":    "vor {count} Tagen",
+		"relDayIn.one":       "in {count} Tag",
+		"relDayIn.other":     "in {count} Tagen",
+		"relMonthAgo.one":    "vor {count} Monat",
+		"relMonthAgo.other":  "vor {count} Monaten",
+		"relMonthIn.one":     "in {count} Monat",
+		"relMonthIn.other":   "in {count} Monaten",
+		"relYearAgo.one":     "vor {count} Jahr",
+		"relYearAgo.other":   "vor {count} Jahren",
+		"relYearIn.one":      "in {count} Jahr",
+		"relYearIn.other":    "in {count} Jahren",
+	})
+
+	// Sadly for the British, 'en' happens to be the short version of 'en-US'
+	LangAlias("en", "en-US")
+}
@@ -0,0 +1,460 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func (l *LangPackage) lookup(name string) (value, code string, ok bool) {
+	for _, pack := range []*langPackage{l.langPackage, l.fallback} {
+		if pack == nil {
+			continue
+		}
+		pack._m.RLock()
+		value, ok = pack.m[name]
+		pack._m.RUnlock()
+		if ok && value != "" {
+			return value, pack.code, true
+		}
+	}
+	return "", "", false
+}
+
+func pluralCount(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// Replace {name} placeholders with args, unknown placeholders are kept.
+func langInterpolate(s string, args map[string]interface{}) string {
+	if len(args) == 0 || !strings.Contains(s, "{") {
+		return s
+	}
+	out := &strings.Builder{}
+	for {
+		start := strings.Index(s, "{")
+		if start == -1 {
+			break
+		}
+		end := strings.Index(s[start:], "}")
+		if end == -1 {
+			break
+		}
+		end += start
+		out.WriteString(s[:start])
+		if value, ok := args[s[start+1:end]]; ok {
+			fmt.Fprint(out, value)
+		} else {
+			out.WriteString(s[start : end+1])
+		}
+		s = s[end+1:]
+	}
+	out.WriteString(s)
+	return out.String()
+}
+
+// Translate key with {name} placeholders. If args has "count", the plural form
+// key.<category> (e.g. items.one, items.few) is used, falling back to key.other and key.
+// Returns key if not found.
+func (l *LangPackage) T(key string, args map[string]interface{}) string {
+	if n, ok := pluralCount(args["count"]); ok {
+		for _, pack := range []*langPackage{l.langPackage, l.fallback} {
+			if pack == nil {
+				continue
+			}
+			pack._m.RLock()
+			value := pack.m[key+"."+PluralCategory(pack.code, n)]
+			if value == "" {
+				value = pack.m[key+"."+PluralOther]
+			}
+			pack._m.RUnlock()
+			if value != "" {
+				return langInterpolate(value, args)
+			}
+		}
+	}
+
+	if value, _, ok := l.lookup(key); ok {
+		return langInterpolate(value, args)
+	}
+	return key
+}
+
+// Translate key of package "core", see LangPackage.T
+func (l *Lang) T(key string, args map[string]interface{}) string {
+	return l.Package("core").T(key, args)
+}
+
+// Flatten nested objects with ".", e.g. {"items": {"one": "..."}} to "items.one".
+func langFlatten(prefix string, v interface{}, out map[string]string) error {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for key, value := range t {
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+			if err := langFlatten(key, value, out); err != nil {
+				return err
+			}
+		}
+	case string:
+		out[prefix] = t
+	default:
+		return ErrorStr("Catalog value of '" + prefix + "' is not a string or object")
+	}
+	return nil
+}
+
+// Load JSON Catalog, nested objects are joined with ".".
+func LangLoadJSON(langCode, _package string, r io.Reader) error {
+	v := map[string]interface{}{}
+	if err := json.NewDecoder(r).Decode(&v); err != nil {
+		return err
+	}
+	keyValue := map[string]string{}
+	if err := langFlatten("", v, keyValue); err != nil {
+		return err
+	}
+	LangKeyValueRegister(langCode, _package, keyValue)
+	return nil
+}
+
+func tomlKey(key string) (string, error) {
+	parts := []string{}
+	for _, part := range strings.Split(key, ".") {
+		part = strings.TrimSpace(part)
+		if strings.HasPrefix(part, `"`) {
+			unquoted, err := strconv.Unquote(part)
+			if err != nil {
+				return "", err
+			}
+			part = unquoted
+		}
+		if part == "" {
+			return "", ErrorStr("Blank TOML key")
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "."), nil
+}
+
+func tomlString(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case strings.HasPrefix(value, `"`):
+		end := 1
+		for ; end < len(value); end++ {
+			if value[end] == '\\' {
+				end++
+				continue
+			}
+			if value[end] == '"' {
+				break
+			}
+		}
+		if end >= len(value) {
+			return "", ErrorStr("Unterminated TOML string")
+		}
+		if rest := strings.TrimSpace(value[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", ErrorStr("Unexpected TOML after string: " + rest)
+		}
+		return strconv.Unquote(value[:end+1])
+	case strings.HasPrefix(value, "'"):
+		end := strings.Index(value[1:], "'")
+		if end == -1 {
+			return "", ErrorStr("Unterminated TOML string")
+		}
+		return value[1 : end+1], nil
+	}
+	return "", ErrorStr("TOML value is not a string: " + value)
+}
+
+// Load TOML Catalog, string values with [table] and dotted keys.
+func LangLoadTOML(langCode, _package string, r io.Reader) error {
+	keyValue := map[string]string{}
+	table := ""
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(text, "[") {
+			end := strings.Index(text, "]")
+			if end == -1 {
+				return ErrorStr(fmt.Sprintf("TOML line %d: unterminated table", line))
+			}
+			key, err := tomlKey(text[1:end])
+			if err != nil {
+				return ErrorStr(fmt.Sprintf("TOML line %d: %v", line, err))
+			}
+			table = key
+			continue
+		}
+
+		pos := strings.Index(text, "=")
+		if pos == -1 {
+			return ErrorStr(fmt.Sprintf("TOML line %d: expected key = value", line))
+		}
+		key, err := tomlKey(text[:pos])
+		if err != nil {
+			return ErrorStr(fmt.Sprintf("TOML line %d: %v", line, err))
+		}
+		value, err := tomlString(text[pos+1:])
+		if err != nil {
+			return ErrorStr(fmt.Sprintf("TOML line %d: %v", line, err))
+		}
+		if table != "" {
+			key = table + "." + key
+		}
+		keyValue[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	LangKeyValueRegister(langCode, _package, keyValue)
+	return nil
+}
+
+type poEntry struct {
+	context string
+	id      string
+	plural  bool
+	strs    map[int]string
+}
+
+func poUnquote(s string) (string, error) {
+	return strconv.Unquote(strings.TrimSpace(s))
+}
+
+// Load gettext PO Catalog, msgid is the key. Plural forms msgstr[n] are mapped to
+// CLDR categories of langCode in order, e.g. en msgstr[0] to key.one and msgstr[1] to key.other.
+// msgctxt is prefixed to the key with ".", fuzzy entries are skipped.
+func LangLoadPO(langCode, _package string, r io.Reader) error {
+	keyValue := map[string]string{}
+	categories := PluralCategories(langCode)
+
+	entry := &poEntry{strs: map[int]string{}}
+	fuzzy := false
+	// Appends continuation lines to the last keyword
+	var appendTo func(string)
+
+	flush := func() {
+		defer func() {
+			entry, fuzzy, appendTo = &poEntry{strs: map[int]string{}}, false, nil
+		}()
+		if entry.id == "" || fuzzy {
+			return
+		}
+		key := entry.id
+		if entry.context != "" {
+			key = entry.context + "." + key
+		}
+		if !entry.plural {
+			if entry.strs[0] != "" {
+				keyValue[key] = entry.strs[0]
+			}
+			return
+		}
+		for i, category := range categories {
+			if entry.strs[i] != "" {
+				keyValue[key+"."+category] = entry.strs[i]
+			}
+		}
+		// Fewer gettext forms than CLDR categories, last form is other.
+		if keyValue[key+"."+PluralOther] == "" && len(entry.strs) > 0 {
+			keyValue[key+"."+PluralOther] = entry.strs[len(entry.strs)-1]
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		fail := func(err error) error {
+			return ErrorStr(fmt.Sprintf("PO line %d: %v", line, err))
+		}
+
+		switch {
+		case text == "":
+			flush()
+		case strings.HasPrefix(text, "#,"):
+			fuzzy = fuzzy || strings.Contains(text, "fuzzy")
+		case strings.HasPrefix(text, "#"):
+		case strings.HasPrefix(text, `"`):
+			if appendTo == nil {
+				return fail(ErrorStr("continuation without keyword"))
+			}
+			s, err := poUnquote(text)
+			if err != nil {
+				return fail(err)
+			}
+			appendTo(s)
+		case strings.HasPrefix(text, "msgctxt "):
+			if entry.id != "" || len(entry.strs) > 0 {
+				flush()
+			}
+			s, err := poUnquote(text[len("msgctxt "):])
+			if err != nil {
+				return fail(err)
+			}
+			entry.context = s
+			appendTo = func(s string) { entry.context += s }
+		case strings.HasPrefix(text, "msgid_plural "):
+			entry.plural = true
+			appendTo = func(string) {}
+		case strings.HasPrefix(text, "msgid "):
+			if entry.id != "" || len(entry.strs) > 0 {
+				flush()
+			}
+			s, err := poUnquote(text[len("msgid "):])
+			if err != nil {
+				return fail(err)
+			}
+			entry.id = s
+			appendTo = func(s string) { entry.id += s }
+		case strings.HasPrefix(text, "msgstr["):
+			end := strings.Index(text, "]")
+			if end == -1 {
+				return fail(ErrorStr("unterminated msgstr index"))
+			}
+			index, err := strconv.Atoi(text[len("msgstr["):end])
+			if err != nil {
+				return fail(err)
+			}
+			s, err := poUnquote(text[end+1:])
+			if err != nil {
+				return fail(err)
+			}
+			entry.strs[index] = s
+			appendTo = func(s string) { entry.strs[index] += s }
+		case strings.HasPrefix(text, "msgstr "):
+			s, err := poUnquote(text[len("msgstr "):])
+			if err != nil {
+				return fail(err)
+			}
+			entry.strs[0] = s
+			appendTo = func(s string) { entry.strs[0] += s }
+		default:
+			return fail(ErrorStr("unexpected " + text))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	flush()
+
+	LangKeyValueRegister(langCode, _package, keyValue)
+	return nil
+}
+
+// Load Catalog File by extension: .json, .toml or .po
+func LangLoadFile(langCode, _package, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return LangLoadJSON(langCode, _package, file)
+	case ".toml":
+		return LangLoadTOML(langCode, _package, file)
+	case ".po":
+		return LangLoadPO(langCode, _package, file)
+	}
+	return ErrorStr("Unknown catalog format: " + path)
+}
+
+// Key without plural category suffix
+func langBaseKey(key string) string {
+	if pos := strings.LastIndex(key, "."); pos != -1 {
+		for _, category := range pluralCategories {
+			if key[pos+1:] == category {
+				return key[:pos]
+			}
+		}
+	}
+	return key
+}
+
+func langKeys(langCode, _package string) map[string]bool {
+	langs._m.RLock()
+	defer langs._m.RUnlock()
+	keys := map[string]bool{}
+	if langs.m[langCode] == nil {
+		return keys
+	}
+	langs.m[langCode]._m.RLock()
+	pack := langs.m[langCode].m[_package]
+	langs.m[langCode]._m.RUnlock()
+	if pack == nil {
+		return keys
+	}
+	pack._m.RLock()
+	defer pack._m.RUnlock()
+	for key := range pack.m {
+		keys[langBaseKey(key)] = true
+	}
+	return keys
+}
+
+func langKeysDiff(a, b map[string]bool) []string {
+	diff := []string{}
+	for key := range a {
+		if !b[key] {
+			diff = append(diff, key)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
+// Keys of reference locale missing in langCode, plural forms count as one key.
+func LangMissingKeys(reference, langCode, _package string) []string {
+	return langKeysDiff(langKeys(reference, _package), langKeys(langCode, _package))
+}
+
+// Keys of langCode not in reference locale, plural forms count as one key.
+func LangUnusedKeys(reference, langCode, _package string) []string {
+	return langKeysDiff(langKeys(langCode, _package), langKeys(reference, _package))
+}
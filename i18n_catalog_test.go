@@ -0,0 +1,116 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPluralCategory(t *testing.T) {
+	for _, test := range []struct {
+		locale   string
+		n        float64
+		category string
+	}{
+		{"en-GB", 1, PluralOne},
+		{"en-GB", 0, PluralOther},
+		{"en-GB", 1.5, PluralOther},
+		{"fr-FR", 0, PluralOne},
+		{"fr-FR", 1.5, PluralOne},
+		{"pt-BR", 0, PluralOne},
+		{"pt-PT", 0, PluralOther},
+		{"ru", 21, PluralOne},
+		{"ru", 11, PluralMany},
+		{"ru", 23, PluralFew},
+		{"ru", 1.5, PluralOther},
+		{"pl", 22, PluralFew},
+		{"pl", 21, PluralMany},
+		{"ar", 0, PluralZero},
+		{"ar", 105, PluralFew},
+		{"ja", 1, PluralOther},
+	} {
+		if PluralCategory(test.locale, test.n) != test.category {
+			t.Fail()
+		}
+	}
+
+	if strings.Join(PluralCategories("ru"), ",") != "one,few,many,other" {
+		t.Fail()
+	}
+}
+
+func TestLangCatalog(t *testing.T) {
+	Check(LangLoadJSON("en-GB", "test-catalog", strings.NewReader(`{
+		"greeting": "Hello {name}",
+		"items": {"one": "{count} item", "other": "{count} items"},
+		"onlyEnglish": "English"
+	}`)))
+
+	Check(LangLoadTOML("ru", "test-catalog", strings.NewReader(`
+# Russian
+greeting = "Привет {name}"
+
+[items]
+one = "{count} предмет"
+few = '{count} предмета'
+many = "{count} предметов"
+"other" = "{count} предмета" # fractions
+`)))
+
+	Check(LangLoadPO("pl", "test-catalog", strings.NewReader(`
+msgid ""
+msgstr ""
+"Plural-Forms: nplurals=3;\n"
+
+msgid "greeting"
+msgstr "Cześć "
+"{name}"
+
+msgid "items"
+msgid_plural "items"
+msgstr[0] "{count} przedmiot"
+msgstr[1] "{count} przedmioty"
+msgstr[2] "{count} przedmiotów"
+
+#, fuzzy
+msgid "extra"
+msgstr "Nieużywany"
+`)))
+
+	pack := func(code string) *LangPackage {
+		c := &Context{App: NewApp()}
+		c.Pub.LangCode = code
+		return c.Lang().Package("test-catalog")
+	}
+
+	for _, test := range []struct {
+		code, key string
+		args      map[string]interface{}
+		expected  string
+	}{
+		{"en-GB", "greeting", map[string]interface{}{"name": "Bob"}, "Hello Bob"},
+		{"en-GB", "items", map[string]interface{}{"count": 1}, "1 item"},
+		{"en-GB", "items", map[string]interface{}{"count": 2}, "2 items"},
+		{"en-GB", "missing", nil, "missing"},
+		{"ru", "greeting", map[string]interface{}{"name": "Боб", "unused": 1}, "Привет Боб"},
+		{"ru", "items", map[string]interface{}{"count": 22}, "22 предмета"},
+		{"ru", "items", map[string]interface{}{"count": 25}, "25 предметов"},
+		{"ru", "onlyEnglish", nil, "English"},
+		{"pl", "greeting", map[string]interface{}{"name": "Ola"}, "Cześć Ola"},
+		{"pl", "items", map[string]interface{}{"count": 5}, "5 przedmiotów"},
+		{"pl", "items", map[string]interface{}{"count": 1.5}, "1.5 przedmiotów"},
+		{"pl", "extra", nil, "extra"},
+	} {
+		if pack(test.code).T(test.key, test.args) != test.expected {
+			t.Fail()
+		}
+	}
+
+	if strings.Join(LangMissingKeys("en-GB", "ru", "test-catalog"), ",") != "onlyEnglish" {
+		t.Fail()
+	}
+
+	LangKeyValueRegister("ru", "test-catalog", map[string]string{"stale": "x"})
+	if strings.Join(LangUnusedKeys("en-GB", "ru", "test-catalog"), ",") != "stale" {
+		t.Fail()
+	}
+}
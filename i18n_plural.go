@@ -0,0 +1,179 @@
+package core
+
+import (
+	"math"
+	"strings"
+)
+
+// CLDR Plural Categories
+const (
+	PluralZero  = "zero"
+	PluralOne   = "one"
+	PluralTwo   = "two"
+	PluralFew   = "few"
+	PluralMany  = "many"
+	PluralOther = "other"
+)
+
+var pluralCategories = []string{PluralZero, PluralOne, PluralTwo, PluralFew, PluralMany, PluralOther}
+
+// Plural Rule, returns CLDR category of n.
+type PluralRule func(n float64) string
+
+func pluralOperands(n float64) (i int64, fraction bool) {
+	n = math.Abs(n)
+	return int64(n), n != math.Trunc(n)
+}
+
+func pluralOneOther(n float64) string {
+	if i, fraction := pluralOperands(n); i == 1 && !fraction {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+func pluralOther(n float64) string {
+	return PluralOther
+}
+
+// French and Brazilian Portuguese, 0 and 1 are singular
+func pluralZeroOneOther(n float64) string {
+	if i, _ := pluralOperands(n); i == 0 || i == 1 {
+		return PluralOne
+	}
+	return PluralOther
+}
+
+// Russian, Ukrainian and Belarusian
+func pluralEastSlavic(n float64) string {
+	i, fraction := pluralOperands(n)
+	if fraction {
+		return PluralOther
+	}
+	switch {
+	case i%10 == 1 && i%100 != 11:
+		return PluralOne
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		return PluralFew
+	}
+	return PluralMany
+}
+
+func pluralPolish(n float64) string {
+	i, fraction := pluralOperands(n)
+	if fraction {
+		return PluralOther
+	}
+	switch {
+	case i == 1:
+		return PluralOne
+	case i%10 >= 2 && i%10 <= 4 && (i%100 < 12 || i%100 > 14):
+		return PluralFew
+	}
+	return PluralMany
+}
+
+// Czech and Slovak
+func pluralCzech(n float64) string {
+	i, fraction := pluralOperands(n)
+	switch {
+	case fraction:
+		return PluralMany
+	case i == 1:
+		return PluralOne
+	case i >= 2 && i <= 4:
+		return PluralFew
+	}
+	return PluralOther
+}
+
+func pluralArabic(n float64) string {
+	i, fraction := pluralOperands(n)
+	if fraction {
+		return PluralOther
+	}
+	switch {
+	case i == 0:
+		return PluralZero
+	case i == 1:
+		return PluralOne
+	case i == 2:
+		return PluralTwo
+	case i%100 >= 3 && i%100 <= 10:
+		return PluralFew
+	case i%100 >= 11:
+		return PluralMany
+	}
+	return PluralOther
+}
+
+func pluralHebrew(n float64) string {
+	i, fraction := pluralOperands(n)
+	switch {
+	case i == 1 && !fraction, i == 0 && fraction:
+		return PluralOne
+	case i == 2 && !fraction:
+		return PluralTwo
+	}
+	return PluralOther
+}
+
+// Plural Rules by language subtag, languages not listed use one (exactly 1) and other.
+var PluralRules = map[string]PluralRule{
+	"fr": pluralZeroOneOther,
+	"ja": pluralOther,
+	"zh": pluralOther,
+	"ko": pluralOther,
+	"th": pluralOther,
+	"vi": pluralOther,
+	"id": pluralOther,
+	"ms": pluralOther,
+	"ru": pluralEastSlavic,
+	"uk": pluralEastSlavic,
+	"be": pluralEastSlavic,
+	"pl": pluralPolish,
+	"cs": pluralCzech,
+	"sk": pluralCzech,
+	"ar": pluralArabic,
+	"he": pluralHebrew,
+}
+
+// Plural Rules by full locale, checked before PluralRules, e.g. Brazilian Portuguese differs from Portugal.
+var PluralRulesLocale = map[string]PluralRule{
+	"pt-BR": pluralZeroOneOther,
+}
+
+func pluralRule(locale string) PluralRule {
+	locale = LocaleNormalise(locale)
+	if rule := PluralRulesLocale[locale]; rule != nil {
+		return rule
+	}
+	if rule := PluralRules[strings.SplitN(locale, "-", 2)[0]]; rule != nil {
+		return rule
+	}
+	return pluralOneOther
+}
+
+// Get CLDR plural category of n in locale
+func PluralCategory(locale string, n float64) string {
+	return pluralRule(locale)(n)
+}
+
+// Categories used by locale in CLDR order, sampled from the rule.
+func PluralCategories(locale string) []string {
+	rule := pluralRule(locale)
+	used := map[string]bool{}
+	for n := 0; n <= 200; n++ {
+		used[rule(float64(n))] = true
+	}
+	used[rule(0.5)] = true
+	used[rule(1.5)] = true
+
+	categories := []string{}
+	for _, category := range pluralCategories {
+		if used[category] {
+			categories = append(categories, category)
+		}
+	}
+	return categories
+}
@@ -1,113 +1,169 @@
-package core
-
-import (
-	"bytes"
-	"io"
-)
-
-// Buffer Shortcut! (c.Pub.Readers and c.Pub.Writers)
-type IO struct {
-	c *Context
-}
-
-// Buffer Shortcut!
-func (c *Context) IO() IO {
-	return IO{c}
-}
-
-// Get Writer
-func (io_ IO) W(name string) io.Writer {
-	return io_.c.Pub.Writers[name]
-}
-
-// Get Reader
-func (io_ IO) R(name string) io.Reader {
-	return io_.c.Pub.Readers[name]
-}
-
-// Push from Reader to Writer
-func (io_ IO) PushRtoW(readerName, writerName string) {
-	r := io_.R(readerName)
-	w := io_.W(writerName)
-	if r == nil || w == nil {
-		return
-	}
-	io.Copy(w, r)
-}
-
-// Push Content to Writer
-func (io_ IO) Push(writerName string, content []byte) {
-	w := io_.W(writerName)
-	if w == nil {
-		return
-	}
-	io.Copy(w, bytes.NewReader(content))
-}
-
-// Push Content to Writer as string
-func (io_ IO) PushStr(writerName, content string) {
-	io_.Push(writerName, []byte(content))
-}
-
-// Push Content to Writer from io.Reader
-func (io_ IO) PushReader(writerName string, r io.Reader) {
-	w := io_.W(writerName)
-	if w == nil {
-		return
-	}
-	io.Copy(w, r)
-}
-
-// Push Content direct to Client
-func (io_ IO) PushToClient(content []byte) {
-	io.Copy(io_.c.Res, bytes.NewReader(content))
-}
-
-// Push Content direct to Client as string
-func (io_ IO) PushToClientStr(content string) {
-	io_.PushToClient([]byte(content))
-}
-
-// Push Content direct to Client from io.Reader
-func (io_ IO) PushToClientReader(r io.Reader) {
-	io.Copy(io_.c.Res, r)
-}
-
-// Pull Content from a Reader as []byte
-func (io_ IO) Pull(readerName string) []byte {
-	r := io_.R(readerName)
-	if r == nil {
-		return nil
-	}
-	b := []byte{}
-	for {
-		bb := make([]byte, 1024)
-		num, _ := r.Read(bb)
-		if num == 0 {
-			break
-		}
-		b = append(b, bb[:num]...)
-	}
-	return b
-}
-
-// Pull Content from a Reader as String
-func (io_ IO) PullStr(readerName string) string {
-	if io_.R(readerName) == nil {
-		return ""
-	}
-	return string(io_.Pull(readerName))
-}
-
-// Pull Content from A Reader to io.Writer
-func (io_ IO) PullWriter(readerName string, w io.Writer) {
-	if io_.R(readerName) == nil {
-		return
-	}
-	io.Copy(w, io_.R(readerName))
-}
-
-// Push Io
-func (io_ IO) PushIO(dst io.Writer, src io.Reader) (int64, error) {
-	return io.Copy(dst, src)
-}
+package core
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+)
+
+// Buffer Shortcut! (c.Pub.Readers and c.Pub.Writers)
+type IO struct {
+	c *Context
+}
+
+// Buffer Shortcut!
+func (c *Context) IO() IO {
+	return IO{c}
+}
+
+// Get Writer
+func (io_ IO) W(name string) io.Writer {
+	return io_.c.Pub.Writers[name]
+}
+
+// Get Reader
+func (io_ IO) R(name string) io.Reader {
+	return io_.c.Pub.Readers[name]
+}
+
+func (io_ IO) writer(name string) (io.Writer, error) {
+	w := io_.W(name)
+	if w == nil {
+		return nil, ErrorStr("Writer '" + name + "' does not exist")
+	}
+	return w, nil
+}
+
+func (io_ IO) reader(name string) (io.Reader, error) {
+	r := io_.R(name)
+	if r == nil {
+		return nil, ErrorStr("Reader '" + name + "' does not exist")
+	}
+	return r, nil
+}
+
+// Push from Reader to Writer
+func (io_ IO) PushRtoW(readerName, writerName string) {
+	io_.TryPushRtoW(readerName, writerName)
+}
+
+// Push from Reader to Writer, returns error if either does not exist or the copy fails.
+func (io_ IO) TryPushRtoW(readerName, writerName string) error {
+	r, err := io_.reader(readerName)
+	if err != nil {
+		return err
+	}
+	return io_.TryPushReader(writerName, r)
+}
+
+// Push Content to Writer
+func (io_ IO) Push(writerName string, content []byte) {
+	io_.TryPush(writerName, content)
+}
+
+// Push Content to Writer, returns error if Writer does not exist or fails (e.g. Pipeline stage).
+func (io_ IO) TryPush(writerName string, content []byte) error {
+	return io_.TryPushReader(writerName, bytes.NewReader(content))
+}
+
+// Push Content to Writer as string
+func (io_ IO) PushStr(writerName, content string) {
+	io_.TryPushStr(writerName, content)
+}
+
+// Push Content to Writer as string, see TryPush
+func (io_ IO) TryPushStr(writerName, content string) error {
+	return io_.TryPush(writerName, []byte(content))
+}
+
+// Push Content to Writer from io.Reader
+func (io_ IO) PushReader(writerName string, r io.Reader) {
+	io_.TryPushReader(writerName, r)
+}
+
+// Push Content to Writer from io.Reader, see TryPush
+func (io_ IO) TryPushReader(writerName string, r io.Reader) error {
+	w, err := io_.writer(writerName)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
+
+// Push Content direct to Client
+func (io_ IO) PushToClient(content []byte) {
+	io_.TryPushToClient(content)
+}
+
+// Push Content direct to Client, returns write error
+func (io_ IO) TryPushToClient(content []byte) error {
+	return io_.TryPushToClientReader(bytes.NewReader(content))
+}
+
+// Push Content direct to Client as string
+func (io_ IO) PushToClientStr(content string) {
+	io_.TryPushToClient([]byte(content))
+}
+
+// Push Content direct to Client as string, returns write error
+func (io_ IO) TryPushToClientStr(content string) error {
+	return io_.TryPushToClient([]byte(content))
+}
+
+// Push Content direct to Client from io.Reader
+func (io_ IO) PushToClientReader(r io.Reader) {
+	io_.TryPushToClientReader(r)
+}
+
+// Push Content direct to Client from io.Reader, returns write error
+func (io_ IO) TryPushToClientReader(r io.Reader) error {
+	_, err := io.Copy(io_.c.Res, r)
+	return err
+}
+
+// Pull Content from a Reader as []byte, nil if Reader does not exist.
+func (io_ IO) Pull(readerName string) []byte {
+	b, _ := io_.TryPull(readerName)
+	return b
+}
+
+// Pull Content from a Reader as []byte, returns error if Reader does not exist or fails.
+func (io_ IO) TryPull(readerName string) ([]byte, error) {
+	r, err := io_.reader(readerName)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
+
+// Pull Content from a Reader as String
+func (io_ IO) PullStr(readerName string) string {
+	return string(io_.Pull(readerName))
+}
+
+// Pull Content from a Reader as String, see TryPull
+func (io_ IO) TryPullStr(readerName string) (string, error) {
+	b, err := io_.TryPull(readerName)
+	return string(b), err
+}
+
+// Pull Content from A Reader to io.Writer
+func (io_ IO) PullWriter(readerName string, w io.Writer) {
+	io_.TryPullWriter(readerName, w)
+}
+
+// Pull Content from A Reader to io.Writer, see TryPull
+func (io_ IO) TryPullWriter(readerName string, w io.Writer) error {
+	r, err := io_.reader(readerName)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, r)
+	return err
+}
+
+// Push Io
+func (io_ IO) PushIO(dst io.Writer, src io.Reader) (int64, error) {
+	return io.Copy(dst, src)
+}
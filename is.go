@@ -1,29 +1,42 @@
-package core
-
-type Is struct {
-	c *Context
-}
-
-func (c *Context) Is() Is {
-	return Is{c}
-}
-
-// Is Ajax Request
-func (i Is) AjaxRequest() bool {
-	return i.c.Req.Header.Get("X-Requested-With") == "XMLHttpRequest"
-}
-
-// Is WebSocket Request
-func (i Is) WebSocketRequest() bool {
-	return i.c.Req.Header.Get("Connection") == "Upgrade" && i.c.Req.Header.Get("Upgrade") == "websocket"
-}
-
-// Is Do Not Track
-func (i Is) DNT() bool {
-	return i.c.Req.Header.Get("Dnt") == "1" || i.c.Req.Header.Get("X-Do-Not-Track") == "1"
-}
-
-// Is Secure
-func (i Is) Secure() bool {
-	return i.c.pri.secure
-}
+package core
+
+import (
+	"strings"
+)
+
+type Is struct {
+	c *Context
+}
+
+func (c *Context) Is() Is {
+	return Is{c}
+}
+
+// Is Ajax Request
+func (i Is) AjaxRequest() bool {
+	return i.c.Req.Header.Get("X-Requested-With") == "XMLHttpRequest"
+}
+
+// Is WebSocket Request
+func (i Is) WebSocketRequest() bool {
+	return headerHasToken(i.c.Req.Header, "Connection", "upgrade") && headerHasToken(i.c.Req.Header, "Upgrade", "websocket")
+}
+
+// Is Do Not Track
+func (i Is) DNT() bool {
+	return i.c.Req.Header.Get("Dnt") == "1" || i.c.Req.Header.Get("X-Do-Not-Track") == "1"
+}
+
+// Is Secure
+func (i Is) Secure() bool {
+	return i.c.pri.secure
+}
+
+// Is Client expecting Json (e.g. API Client)
+func (i Is) JsonAccepted() bool {
+	accept := i.c.Req.Header.Get("Accept")
+	if strings.Contains(accept, "text/html") {
+		return false
+	}
+	return strings.Contains(accept, "json") || i.AjaxRequest()
+}
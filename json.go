@@ -1,53 +1,74 @@
-package core
-
-import (
-	"encoding/json"
-	"io"
-)
-
-type Json struct {
-	c *Context
-}
-
-func (c *Context) Json() Json {
-	return Json{c}
-}
-
-// Shortcut to encoding/json.Marshal
-func (j Json) Marshal(v interface{}) ([]byte, error) {
-	return json.Marshal(v)
-}
-
-// Shortcut to encoding/json.MarshalIndent
-func (j Json) MarshalIndent(v interface{}, prefix, indent string) ([]byte, error) {
-	return json.MarshalIndent(v, prefix, indent)
-}
-
-// Shortcut to encoding/json.Unmarshal
-func (j Json) Unmarshal(data []byte, v interface{}) error {
-	return json.Unmarshal(data, v)
-}
-
-// Shortcut to encoding/json.NewDecoder
-func (j Json) NewDecoder(r io.Reader) *json.Decoder {
-	return json.NewDecoder(r)
-}
-
-// Shortcut to encoding/json.NewEncoder
-func (j Json) NewEncoder(w io.Writer) *json.Encoder {
-	return json.NewEncoder(w)
-}
-
-// Send Json output to client.
-func (j Json) Send(v interface{}) {
-	w := j.c.Pub.Writers["gzip"]
-	if w == nil {
-		w = j.c.Res
-	}
-	j.NewEncoder(w).Encode(v)
-}
-
-// Decode Request Body
-func (j Json) DecodeReqBody(v interface{}) error {
-	return j.NewDecoder(j.c.Req.Body).Decode(v)
-}
+package core
+
+import (
+	"encoding/json"
+	"io"
+)
+
+type Json struct {
+	c *Context
+}
+
+func (c *Context) Json() Json {
+	return Json{c}
+}
+
+// Shortcut to encoding/json.Marshal
+func (j Json) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Shortcut to encoding/json.MarshalIndent
+func (j Json) MarshalIndent(v interface{}, prefix, indent string) ([]byte, error) {
+	return json.MarshalIndent(v, prefix, indent)
+}
+
+// Shortcut to encoding/json.Unmarshal
+func (j Json) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// Shortcut to encoding/json.NewDecoder
+func (j Json) NewDecoder(r io.Reader) *json.Decoder {
+	return json.NewDecoder(r)
+}
+
+// Shortcut to encoding/json.NewEncoder
+func (j Json) NewEncoder(w io.Writer) *json.Encoder {
+	return json.NewEncoder(w)
+}
+
+// Json, Xml and MethodHtml5 output go through the Writer named "gzip" if there is one.
+func (j Json) writer() io.Writer {
+	w := j.c.Pub.Writers["gzip"]
+	if w == nil {
+		w = j.c.Res
+	}
+	return w
+}
+
+// Send Json output to client, Content-Type defaults to application/json.
+// Nothing is written if v cannot be encoded, the error can be passed on to HandleError.
+func (j Json) Send(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	header := j.c.Res.Header()
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	_, err = j.writer().Write(append(b, '\n'))
+	return err
+}
+
+// Send Json output to client with status code, e.g. SendStatus(201, created).
+func (j Json) SendStatus(status int, v interface{}) error {
+	j.c.Pub.Status = status
+	return j.Send(v)
+}
+
+// Decode Request Body
+func (j Json) DecodeReqBody(v interface{}) error {
+	return j.NewDecoder(j.c.Req.Body).Decode(v)
+}
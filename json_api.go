@@ -0,0 +1,342 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+)
+
+// Problem Details for HTTP APIs (RFC 7807), sent as application/problem+json.
+type Problem struct {
+	// URI identifying the problem type, "about:blank" if empty
+	Type string
+	// Short summary, the status text by default
+	Title string
+	// Status Code
+	Status int
+	// Explanation specific to this occurrence
+	Detail string
+	// URI of this occurrence
+	Instance string
+	// Extension members, e.g. "errors" for validation failures
+	Extensions map[string]interface{}
+}
+
+// Construct New Problem from error, HTTPError Message and Details become detail and "errors".
+// Internal causes are never exposed.
+func NewProblem(status int, err error) *Problem {
+	p := &Problem{Status: status, Title: http.StatusText(status)}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		p.Detail = httpErr.Message
+		if httpErr.Details != nil {
+			p.Extensions = map[string]interface{}{"errors": httpErr.Details}
+		}
+	}
+	return p
+}
+
+// Implement json.Marshaler, extension members are merged into the object.
+func (p *Problem) MarshalJSON() ([]byte, error) {
+	out := map[string]interface{}{}
+	for key, value := range p.Extensions {
+		out[key] = value
+	}
+	if p.Type != "" {
+		out["type"] = p.Type
+	}
+	out["title"] = p.Title
+	out["status"] = p.Status
+	if p.Detail != "" {
+		out["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		out["instance"] = p.Instance
+	}
+	return json.Marshal(out)
+}
+
+func (p *Problem) Error() string {
+	msg := fmt.Sprint(p.Status, " ", p.Title)
+	if p.Detail != "" {
+		msg += ": " + p.Detail
+	}
+	return msg
+}
+
+// Send Problem to client with its status code.
+func (j Json) Problem(p *Problem) error {
+	if p.Status == 0 {
+		p.Status = 500
+	}
+	if p.Title == "" {
+		p.Title = http.StatusText(p.Status)
+	}
+	if p.Instance == "" {
+		p.Instance = j.c.Req.URL.RequestURI()
+	}
+	j.c.Res.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
+	return j.SendStatus(p.Status, p)
+}
+
+// Error Handler sending problem+json, e.g. app.RegisterError(Error4xx, ProblemErrorHandler).
+// DefaultErrorHandler uses it for clients accepting application/problem+json.
+var ProblemErrorHandler ErrorHandler = func(c *Context, err error) {
+	var p *Problem
+	if !errors.As(err, &p) {
+		p = NewProblem(c.Pub.Status, err)
+	}
+	c.Json().Problem(p)
+}
+
+// Offset Pagination, from query parameters "offset" and "limit".
+type Pagination struct {
+	c      *Context
+	Offset int
+	Limit  int
+}
+
+func (j Json) queryInt(name string, def int) (int, error) {
+	value := j.c.Req.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, ErrBadRequest("Invalid query parameter '" + name + "'")
+	}
+	return n, nil
+}
+
+// Get Offset Pagination, limit is defaultLimit if not requested and is capped to maxLimit (zero means no cap).
+// Invalid parameters return HTTPError 400.
+func (j Json) Paginate(defaultLimit, maxLimit int) (Pagination, error) {
+	p := Pagination{c: j.c}
+	var err error
+	if p.Offset, err = j.queryInt("offset", 0); err != nil {
+		return p, err
+	}
+	if p.Limit, err = j.queryInt("limit", defaultLimit); err != nil {
+		return p, err
+	}
+	p.Limit = pageLimit(p.Limit, defaultLimit, maxLimit)
+	return p, nil
+}
+
+// Limit capped to maxLimit (zero means no cap), never below one.
+func pageLimit(limit, defaultLimit, maxLimit int) int {
+	if maxLimit > 0 && (limit == 0 || limit > maxLimit) {
+		limit = maxLimit
+	}
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	if limit <= 0 {
+		limit = 1
+	}
+	return limit
+}
+
+// Request URI with query parameter changed
+func pageURL(c *Context, values map[string]string) string {
+	u := *c.Req.URL
+	query := u.Query()
+	for key, value := range values {
+		if value == "" {
+			query.Del(key)
+			continue
+		}
+		query.Set(key, value)
+	}
+	u.RawQuery = query.Encode()
+	return u.RequestURI()
+}
+
+func addLink(c *Context, href, rel string) {
+	c.Res.Header().Add("Link", "<"+href+`>; rel="`+rel+`"`)
+}
+
+// Set Link header (first, prev, next and last) and X-Total-Count from total number of items.
+func (p Pagination) Links(total int) {
+	link := func(offset int, rel string) {
+		addLink(p.c, pageURL(p.c, map[string]string{
+			"offset": strconv.Itoa(offset),
+			"limit":  strconv.Itoa(p.Limit),
+		}), rel)
+	}
+
+	p.c.Res.Header().Set("X-Total-Count", strconv.Itoa(total))
+	link(0, "first")
+	if p.Offset > 0 {
+		prev := p.Offset - p.Limit
+		if prev < 0 {
+			prev = 0
+		}
+		link(prev, "prev")
+	}
+	if p.Offset+p.Limit < total {
+		link(p.Offset+p.Limit, "next")
+	}
+	last := 0
+	if total > 0 {
+		last = (total - 1) / p.Limit * p.Limit
+	}
+	link(last, "last")
+}
+
+// Cursor Pagination, from query parameters "cursor" and "limit".
+// Cursor is opaque and empty for the first page.
+type CursorPagination struct {
+	c      *Context
+	Cursor string
+	Limit  int
+}
+
+// Get Cursor Pagination, limit is defaultLimit if not requested and is capped to maxLimit (zero means no cap).
+// Invalid parameters return HTTPError 400.
+func (j Json) PaginateCursor(defaultLimit, maxLimit int) (CursorPagination, error) {
+	p := CursorPagination{c: j.c, Cursor: j.c.Req.URL.Query().Get("cursor")}
+	var err error
+	if p.Limit, err = j.queryInt("limit", defaultLimit); err != nil {
+		return p, err
+	}
+	p.Limit = pageLimit(p.Limit, defaultLimit, maxLimit)
+	return p, nil
+}
+
+// Set Link header, next is the cursor of the next page, empty if this is the last page.
+func (p CursorPagination) Links(next string) {
+	limit := strconv.Itoa(p.Limit)
+	addLink(p.c, pageURL(p.c, map[string]string{"cursor": "", "limit": limit}), "first")
+	if next != "" {
+		addLink(p.c, pageURL(p.c, map[string]string{"cursor": next, "limit": limit}), "next")
+	}
+}
+
+// Streaming Json Array, elements are encoded and written one at a time.
+// Close must be called to end the array.
+type JsonArray struct {
+	j      Json
+	count  int
+	closed bool
+}
+
+// Start streaming Json Array
+func (j Json) Array() *JsonArray {
+	header := j.c.Res.Header()
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+	return &JsonArray{j: j}
+}
+
+// Write element
+func (a *JsonArray) Write(v interface{}) error {
+	if a.closed {
+		return ErrorStr("JsonArray is closed")
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	prefix := byte(',')
+	if a.count == 0 {
+		prefix = '['
+	}
+	a.count++
+	_, err = a.j.writer().Write(append([]byte{prefix}, b...))
+	return err
+}
+
+// Number of elements written
+func (a *JsonArray) Len() int {
+	return a.count
+}
+
+// Send buffered data to the client
+func (a *JsonArray) Flush() {
+	a.j.flush()
+}
+
+// End Json Array
+func (a *JsonArray) Close() error {
+	if a.closed {
+		return nil
+	}
+	a.closed = true
+	end := "]\n"
+	if a.count == 0 {
+		end = "[]\n"
+	}
+	_, err := a.j.writer().Write([]byte(end))
+	return err
+}
+
+// Newline delimited Json stream (application/x-ndjson), each value is flushed to the client.
+type JsonLines struct {
+	j Json
+}
+
+// Start streaming newline delimited Json
+func (j Json) Lines() JsonLines {
+	j.c.Res.Header().Set("Content-Type", "application/x-ndjson")
+	return JsonLines{j}
+}
+
+// Write value as one line and flush
+func (l JsonLines) Write(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if _, err = l.j.writer().Write(append(b, '\n')); err != nil {
+		return err
+	}
+	l.j.flush()
+	return nil
+}
+
+// Flush writer (e.g. "gzip" Pipeline) through to the client
+func (j Json) flush() {
+	if fl, ok := j.writer().(http.Flusher); ok {
+		fl.Flush()
+	}
+}
+
+var jsonpCallback = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*(\.[A-Za-z_$][A-Za-z0-9_$]*)*$`)
+
+// Max length of JSONP callback name
+var JsonpCallbackMaxLen = 128
+
+// Is valid JSONP callback, e.g. "cb" or "jQuery123.handle".
+func JsonpCallbackValid(callback string) bool {
+	return len(callback) <= JsonpCallbackMaxLen && jsonpCallback.MatchString(callback)
+}
+
+// Send JSONP for legacy clients, callback is read from query parameter param (e.g. "callback").
+// Sends plain Json if there is no callback, invalid callback returns HTTPError 400 without writing.
+func (j Json) SendP(param string, v interface{}) error {
+	callback := j.c.Req.URL.Query().Get(param)
+	if callback == "" {
+		return j.Send(v)
+	}
+	if !JsonpCallbackValid(callback) {
+		return ErrBadRequest("Invalid JSONP callback")
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	header := j.c.Res.Header()
+	header.Set("Content-Type", "application/javascript; charset=utf-8")
+	header.Set("X-Content-Type-Options", "nosniff")
+	// Leading comment prevents the response being read as other content (e.g. Flash).
+	_, err = j.writer().Write([]byte("/**/" + callback + "(" + string(b) + ");\n"))
+	return err
+}
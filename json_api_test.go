@@ -0,0 +1,211 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestJsonApi(t *testing.T) {
+	App := NewApp()
+
+	App.DefaultRouter = NewDirRouter().Register("created", RouteHandlerFunc(func(c *Context) {
+		if c.Json().Send(func() {}) == nil {
+			t.Fail()
+		}
+		c.Json().SendStatus(201, map[string]int{"id": 1})
+	})).Register("invalid", RouteHandlerFunc(func(c *Context) {
+		c.HandleError(ErrUnprocessable("Invalid input").WithDetail("name", "required"))
+	})).Register("items", RouteHandlerFunc(func(c *Context) {
+		page, err := c.Json().Paginate(10, 50)
+		if err != nil {
+			c.HandleError(err)
+			return
+		}
+		page.Links(95)
+		c.Json().Send([]int{page.Offset, page.Limit})
+	})).Register("cursor", RouteHandlerFunc(func(c *Context) {
+		page, _ := c.Json().PaginateCursor(10, 50)
+		page.Links("abc")
+		c.Json().Send(page.Cursor)
+	})).Register("array", RouteHandlerFunc(func(c *Context) {
+		a := c.Json().Array()
+		for i := 1; i <= 3; i++ {
+			a.Write(i)
+		}
+		a.Close()
+		if a.Write(4) == nil {
+			t.Fail()
+		}
+	})).Register("empty", RouteHandlerFunc(func(c *Context) {
+		c.Json().Array().Close()
+	})).Register("lines", RouteHandlerFunc(func(c *Context) {
+		l := c.Json().Lines()
+		l.Write(map[string]int{"a": 1})
+		l.Write(map[string]int{"b": 2})
+	})).Register("jsonp", RouteHandlerFunc(func(c *Context) {
+		if err := c.Json().SendP("callback", map[string]string{"a": "b"}); err != nil {
+			c.HandleError(err)
+		}
+	}))
+
+	serve := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Accept", accept)
+		res := httptest.NewRecorder()
+		App.ServeHTTP(res, req)
+		return res
+	}
+
+	res := serve("/created", "application/json")
+	if res.Code != 201 || res.Body.String() != "{\"id\":1}\n" || res.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Errorf("%d %q", res.Code, res.Body.String())
+	}
+
+	res = serve("/invalid", "application/problem+json")
+	problem := map[string]interface{}{}
+	json.Unmarshal(res.Body.Bytes(), &problem)
+	if res.Code != 422 || res.Header().Get("Content-Type") != "application/problem+json; charset=utf-8" ||
+		problem["title"] != "Unprocessable Entity" || problem["detail"] != "Invalid input" ||
+		problem["status"] != float64(422) || problem["instance"] != "/invalid" ||
+		problem["errors"].(map[string]interface{})["name"] != "required" {
+		t.Errorf("%d %s", res.Code, res.Body.String())
+	}
+
+	// Plain Json clients keep the existing envelope
+	res = serve("/invalid", "application/json")
+	if !strings.HasPrefix(res.Header().Get("Content-Type"), "application/json") || !strings.Contains(res.Body.String(), `"message":"Invalid input"`) {
+		t.Fail()
+	}
+
+	res = serve("/items?offset=20&limit=10&q=x", "application/json")
+	links := strings.Join(res.Header()["Link"], ", ")
+	if res.Header().Get("X-Total-Count") != "95" || res.Body.String() != "[20,10]\n" ||
+		!strings.Contains(links, `</items?limit=10&offset=0&q=x>; rel="first"`) ||
+		!strings.Contains(links, `</items?limit=10&offset=10&q=x>; rel="prev"`) ||
+		!strings.Contains(links, `</items?limit=10&offset=30&q=x>; rel="next"`) ||
+		!strings.Contains(links, `</items?limit=10&offset=90&q=x>; rel="last"`) {
+		t.Error(links)
+	}
+
+	if res = serve("/items?limit=500", "application/json"); res.Body.String() != "[0,50]\n" {
+		t.Fail()
+	}
+
+	if res = serve("/items?offset=-1", "application/problem+json"); res.Code != 400 {
+		t.Fail()
+	}
+
+	res = serve("/cursor?cursor=xyz", "application/json")
+	links = strings.Join(res.Header()["Link"], ", ")
+	if res.Body.String() != "\"xyz\"\n" || links != `</cursor?limit=10>; rel="first", </cursor?cursor=abc&limit=10>; rel="next"` {
+		t.Error(links)
+	}
+
+	if res = serve("/array", "application/json"); res.Body.String() != "[1,2,3]\n" {
+		t.Fail()
+	}
+
+	if res = serve("/empty", "application/json"); res.Body.String() != "[]\n" {
+		t.Fail()
+	}
+
+	res = serve("/lines", "application/x-ndjson")
+	if res.Header().Get("Content-Type") != "application/x-ndjson" || res.Body.String() != "{\"a\":1}\n{\"b\":2}\n" {
+		t.Fail()
+	}
+
+	res = serve("/jsonp?callback=app.handle", "*/*")
+	if res.Body.String() != "/**/app.handle({\"a\":\"b\"});\n" || res.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Error(res.Body.String())
+	}
+
+	if res = serve("/jsonp", "*/*"); res.Body.String() != "{\"a\":\"b\"}\n" {
+		t.Fail()
+	}
+
+	if res = serve("/jsonp?callback=alert(1)", "*/*"); res.Code != 400 {
+		t.Fail()
+	}
+}
+
+func TestJsonpCallbackValid(t *testing.T) {
+	for callback, valid := range map[string]bool{
+		"cb":                     true,
+		"jQuery_123.done":        true,
+		"$":                      true,
+		"1cb":                    false,
+		"a.":                     false,
+		"a[0]":                   false,
+		"a;alert(1)":             false,
+		strings.Repeat("a", 200): false,
+	} {
+		if JsonpCallbackValid(callback) != valid {
+			t.Error(callback)
+		}
+	}
+}
+
+func TestJsonApiStreamGzip(t *testing.T) {
+	App := NewApp()
+
+	res := httptest.NewRecorder()
+
+	App.DefaultRouter = NewDirRouter().Register("lines", RouteHandlerFunc(func(c *Context) {
+		c.IO().Gzip()
+		l := c.Json().Lines()
+		l.Write(map[string]int{"a": 1})
+
+		// First line reaches the client through the gzip Pipeline before the request ends
+		gz, err := gzip.NewReader(bytes.NewReader(res.Body.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		line, err := bufio.NewReader(gz).ReadString('\n')
+		if err != nil || line != "{\"a\":1}\n" {
+			t.Errorf("%q %v", line, err)
+		}
+
+		l.Write(map[string]int{"b": 2})
+	}))
+
+	req := httptest.NewRequest("GET", "/lines", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	App.ServeHTTP(res, req)
+
+	gz, err := gzip.NewReader(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadAll(gz); string(b) != "{\"a\":1}\n{\"b\":2}\n" || res.Header().Get("Content-Type") != "application/x-ndjson" {
+		t.Errorf("%q", b)
+	}
+}
+
+func TestJsonApiPaginateNoMax(t *testing.T) {
+	App := NewApp()
+
+	App.DefaultRouter = NewDirRouter().Register("items", RouteHandlerFunc(func(c *Context) {
+		page, _ := c.Json().Paginate(10, 0)
+		page.Links(95)
+		cursor, _ := c.Json().PaginateCursor(0, 0)
+		c.Json().Send([]int{page.Limit, cursor.Limit})
+	}))
+
+	for path, expected := range map[string]string{
+		"/items":          "[10,1]\n",
+		"/items?limit=0":  "[10,1]\n",
+		"/items?limit=70": "[70,70]\n",
+	} {
+		res := httptest.NewRecorder()
+		App.ServeHTTP(res, httptest.NewRequest("GET", path, nil))
+		if res.Code != 200 || res.Body.String() != expected {
+			t.Errorf("%s: %d %q", path, res.Code, res.Body.String())
+		}
+	}
+}
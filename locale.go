@@ -0,0 +1,284 @@
+package core
+
+import (
+	"html/template"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Parent locales checked before truncation, e.g. en-AU to en-GB to en.
+// Locales not listed fall back by removing the last subtag.
+var LocaleParents = map[string]string{
+	"en-AU":  "en-GB",
+	"en-NZ":  "en-GB",
+	"en-IE":  "en-GB",
+	"en-IN":  "en-GB",
+	"en-ZA":  "en-GB",
+	"en-SG":  "en-GB",
+	"en-HK":  "en-GB",
+	"es-MX":  "es-419",
+	"es-AR":  "es-419",
+	"es-CO":  "es-419",
+	"es-CL":  "es-419",
+	"es-US":  "es-419",
+	"pt-AO":  "pt-PT",
+	"pt-MZ":  "pt-PT",
+	"zh-HK":  "zh-Hant",
+	"zh-TW":  "zh-Hant",
+	"zh-MO":  "zh-Hant",
+	"zh-CN":  "zh-Hans",
+	"zh-SG":  "zh-Hans",
+	"es-419": "es",
+}
+
+// Normalise BCP 47 tag case, e.g. "EN_gb" to "en-GB" and "zh-hant-tw" to "zh-Hant-TW".
+func LocaleNormalise(tag string) string {
+	subtags := strings.Split(strings.Replace(strings.TrimSpace(tag), "_", "-", -1), "-")
+	for i, subtag := range subtags {
+		switch {
+		case i == 0:
+			subtags[i] = strings.ToLower(subtag)
+		case len(subtag) == 2:
+			subtags[i] = strings.ToUpper(subtag)
+		case len(subtag) == 4:
+			subtags[i] = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		default:
+			subtags[i] = strings.ToLower(subtag)
+		}
+	}
+	return strings.Join(subtags, "-")
+}
+
+// Parent of locale, blank for a language only tag.
+func LocaleParent(tag string) string {
+	tag = LocaleNormalise(tag)
+	if parent := LocaleParents[tag]; parent != "" {
+		return parent
+	}
+	if pos := strings.LastIndex(tag, "-"); pos != -1 {
+		return tag[:pos]
+	}
+	return ""
+}
+
+// Match tag to supported locale through its parents, then by language alone.
+// Returns blank if no supported locale matches.
+func LocaleMatch(tag string, supported []string) string {
+	tag = LocaleNormalise(tag)
+	if tag == "" || tag == "*" {
+		return ""
+	}
+
+	find := func(tag string) string {
+		for _, locale := range supported {
+			if LocaleNormalise(locale) == tag {
+				return locale
+			}
+		}
+		return ""
+	}
+
+	for candidate := tag; candidate != ""; candidate = LocaleParent(candidate) {
+		if locale := find(candidate); locale != "" {
+			return locale
+		}
+	}
+
+	language := strings.SplitN(tag, "-", 2)[0]
+	for _, locale := range supported {
+		if strings.SplitN(LocaleNormalise(locale), "-", 2)[0] == language {
+			return locale
+		}
+	}
+	return ""
+}
+
+// Parse Accept-Language, tags ordered by q-value, q=0 and "*" excluded.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	tags := []weighted{}
+	for _, item := range strings.Split(header, ",") {
+		params := strings.Split(item, ";")
+		tag := strings.TrimSpace(params[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if f, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = f
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag, q})
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].q > tags[j].q
+	})
+
+	out := make([]string, len(tags))
+	for i, tag := range tags {
+		out[i] = tag.tag
+	}
+	return out
+}
+
+// Locale Resolver, see App.LocaleResolvers
+type LocaleResolver interface {
+	// Returns supported locale or blank, match maps a tag to supported locale (blank if unsupported).
+	ResolveLocale(c *Context, match func(tag string) string) string
+}
+
+// Adapter to use ordinary function as LocaleResolver
+type LocaleResolverFunc func(c *Context, match func(tag string) string) string
+
+func (fn LocaleResolverFunc) ResolveLocale(c *Context, match func(tag string) string) string {
+	return fn(c, match)
+}
+
+// First path segment, e.g. /en-GB/about. Matched segment is removed, routers see /about.
+type LocalePath struct{}
+
+func (LocalePath) ResolveLocale(c *Context, match func(tag string) string) string {
+	path := strings.TrimLeft(c.pri.path, "/")
+	segment := strings.SplitN(path, "/", 2)[0]
+	if segment == "" {
+		return ""
+	}
+	// Only exact (case-insensitive) tags, /en-AU/ is not a prefix for en-GB.
+	locale := match(segment)
+	if locale == "" || LocaleNormalise(locale) != LocaleNormalise(segment) {
+		return ""
+	}
+
+	rest := path[len(segment):]
+	c.pri.path = rest
+	c.pri.pathAlt = rest
+	c.pri.curpath += "/" + segment
+	c.pri.localePath = "/" + segment
+	return locale
+}
+
+// Locale stored in cookie, see LocaleCookie.Set
+type LocaleCookie struct {
+	Name string
+}
+
+func (lc LocaleCookie) ResolveLocale(c *Context, match func(tag string) string) string {
+	cookie, err := c.Cookie(lc.Name).Unsigned().Get()
+	if err != nil {
+		return ""
+	}
+	return match(cookie.Value)
+}
+
+// Save locale to cookie for a year
+func (lc LocaleCookie) Set(c *Context, locale string) {
+	c.Cookie(lc.Name).Unsigned().Value(locale).Path("/").MaxAge(365 * 24 * 60 * 60).SaveRes()
+}
+
+// Locale stored in session data, map[string]string or map[string]interface{}.
+type LocaleSession struct {
+	Key string
+}
+
+func (ls LocaleSession) ResolveLocale(c *Context, match func(tag string) string) string {
+	switch data := c.Pub.Session.(type) {
+	case map[string]string:
+		return match(data[ls.Key])
+	case map[string]interface{}:
+		if locale, ok := data[ls.Key].(string); ok {
+			return match(locale)
+		}
+	}
+	return ""
+}
+
+// Accept-Language header, tags tried by q-value.
+type LocaleHeader struct{}
+
+func (LocaleHeader) ResolveLocale(c *Context, match func(tag string) string) string {
+	for _, tag := range ParseAcceptLanguage(c.Req.Header.Get("Accept-Language")) {
+		if locale := match(tag); locale != "" {
+			return locale
+		}
+	}
+	return ""
+}
+
+// Supported locales, App.Locales or every registered language.
+func (app *App) supportedLocales() []string {
+	if len(app.Locales) > 0 {
+		return app.Locales
+	}
+	langs._m.RLock()
+	defer langs._m.RUnlock()
+	locales := make([]string, 0, len(langs.m))
+	for code := range langs.m {
+		locales = append(locales, code)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// Negotiate c.Pub.LangCode through App.LocaleResolvers
+func (c *Context) initLocale() {
+	if len(c.App.LocaleResolvers) == 0 {
+		return
+	}
+
+	supported := c.App.supportedLocales()
+	match := func(tag string) string {
+		return LocaleMatch(tag, supported)
+	}
+
+	for _, resolver := range c.App.LocaleResolvers {
+		if locale := resolver.ResolveLocale(c, match); locale != "" {
+			c.SetLocale(locale)
+			return
+		}
+	}
+}
+
+// Set locale of request, Lang and TimeFormat follow.
+func (c *Context) SetLocale(locale string) {
+	c.Pub.LangCode = locale
+	c.Pub.TimeFormat = c.Lang().Key(c.App.TimeFormat.String())
+}
+
+// Get locale of request
+func (c *Context) Locale() string {
+	return c.Pub.LangCode
+}
+
+// Prefix path with locale segment if resolved by LocalePath, e.g. "/about" to "/en-GB/about".
+func (c *Context) LocaleURL(path string) string {
+	return c.pri.localePath + path
+}
+
+// Template Functions: locale, lang (key of package "core" or given package), dir and localeURL.
+func (c *Context) LocaleFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"locale": c.Locale,
+		"lang": func(key string, _package ...string) string {
+			if len(_package) > 0 {
+				return c.Lang().Package(_package[0]).Key(key)
+			}
+			return c.Lang().Key(key)
+		},
+		"dir": func() string {
+			return c.Lang().Key("dir")
+		},
+		"localeURL": c.LocaleURL,
+	}
+}
@@ -0,0 +1,74 @@
+package core
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLocaleMatch(t *testing.T) {
+	supported := []string{"en-GB", "en", "fr-FR", "es"}
+
+	for tag, expected := range map[string]string{
+		"en-AU":   "en-GB",
+		"EN_gb":   "en-GB",
+		"en-US":   "en",
+		"fr-CA":   "fr-FR",
+		"es-MX":   "es",
+		"de-DE":   "",
+		"*":       "",
+		"zh-Hant": "",
+	} {
+		if LocaleMatch(tag, supported) != expected {
+			t.Fail()
+		}
+	}
+
+	tags := ParseAcceptLanguage("fr;q=0.5, de-DE, en-AU;q=0.8, *;q=0.1, es;q=0")
+	if len(tags) != 3 || tags[0] != "de-DE" || tags[1] != "en-AU" || tags[2] != "fr" {
+		t.Fail()
+	}
+}
+
+func TestLocaleResolvers(t *testing.T) {
+	App := NewApp()
+	App.Locales = []string{"en-GB", "en-US", "fr-FR"}
+	App.LocaleResolvers = []LocaleResolver{LocalePath{}, LocaleCookie{"lang"}, LocaleHeader{}}
+
+	App.DefaultRouter = NewDirRouter().Register("about", RouteHandlerFunc(func(c *Context) {
+		c.Fmt().Print(c.Locale(), " ", c.LocaleURL("/about"), " ", c.Lang().Key("init"))
+	}))
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	get := func(path, cookie, acceptLanguage string) string {
+		req, _ := http.NewRequest("GET", ts.URL+path, nil)
+		if cookie != "" {
+			req.AddCookie(&http.Cookie{Name: "lang", Value: cookie})
+		}
+		req.Header.Set("Accept-Language", acceptLanguage)
+		res, err := http.DefaultClient.Do(req)
+		Check(err)
+		b, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		return string(b)
+	}
+
+	if get("/en-US/about", "fr-FR", "en-AU") != "en-US /en-US/about initialize" {
+		t.Fail()
+	}
+
+	if get("/about", "fr-FR", "en-AU") != "fr-FR /about initialise" {
+		t.Fail()
+	}
+
+	if get("/about", "", "de, en-AU;q=0.9") != "en-GB /about initialise" {
+		t.Fail()
+	}
+
+	if get("/about", "", "de") != "en-GB /about initialise" {
+		t.Fail()
+	}
+}
@@ -0,0 +1,185 @@
+package core
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+func execMethodErrInterface(c *Context, me MethodErrInterface) {
+	t := me.getType()
+	if t == nil {
+		t = reflect.Indirect(reflect.ValueOf(me)).Type()
+		me.setType(t)
+	}
+
+	vc := reflect.New(t)
+
+	view := vc.MethodByName("View")
+	in := make([]reflect.Value, 1)
+	in[0] = reflect.ValueOf(c)
+	view.Call(in)
+
+	c.Auto().PopulateStructFieldsValue(vc, "C")
+
+	if c.Terminated() {
+		return
+	}
+
+	c.App.services.inject(c, vc)
+
+	if c.Terminated() {
+		return
+	}
+
+	in = make([]reflect.Value, 0)
+
+	call := func(name string) bool {
+		out := vc.MethodByName(name).Call(in)
+		if err, _ := out[0].Interface().(error); err != nil {
+			c.HandleError(err)
+			return false
+		}
+		return !c.Terminated()
+	}
+
+	if !call("Prepare") {
+		return
+	}
+
+	is := c.Is()
+
+	if is.WebSocketRequest() {
+		if !call("Ws") {
+			goto finish
+		}
+	}
+
+	switch c.Req.Method {
+	case "GET", "HEAD", "POST":
+		// Do nothing
+	default:
+		goto requestDealer
+	}
+
+	if is.AjaxRequest() {
+		if !call("Ajax") {
+			goto finish
+		}
+	}
+
+requestDealer:
+
+	switch c.Req.Method {
+	case "GET", "HEAD":
+		call("Get")
+	case "POST", "DELETE", "PUT", "PATCH", "OPTIONS":
+		call(strings.Title(strings.ToLower(c.Req.Method)))
+	}
+
+finish:
+
+	vc.MethodByName("Finish").Call(in)
+}
+
+type MethodErrInterface interface {
+	View(*Context)
+	Prepare() error
+	Ws() error
+	Ajax() error
+	Get() error
+	Post() error
+	Delete() error
+	Put() error
+	Patch() error
+	Options() error
+	Finish()
+	getType() reflect.Type
+	setType(reflect.Type)
+
+	asn_Core_0003() // Assert Serial Number
+}
+
+// A Restful Controller, verbs return error instead of calling Check.
+// Use *HTTPError for status code other than 500.
+type MethodErr struct {
+	C  *Context `json:"-" xml:"-"`
+	_t reflect.Type
+	_s sync.RWMutex
+}
+
+func (me *MethodErr) View(c *Context) {
+	me.C = c
+}
+
+func (me *MethodErr) Prepare() error {
+	return nil
+}
+
+// Called for WebSocket upgrade requests after Prepare, see Method.Ws.
+func (me *MethodErr) Ws() error {
+	return nil
+}
+
+func (me *MethodErr) Ajax() error {
+	return nil
+}
+
+func (me *MethodErr) Get() error {
+	return ErrMethodNotAllowed
+}
+
+func (me *MethodErr) Post() error {
+	return ErrMethodNotAllowed
+}
+
+func (me *MethodErr) Delete() error {
+	return ErrMethodNotAllowed
+}
+
+func (me *MethodErr) Put() error {
+	return ErrMethodNotAllowed
+}
+
+func (me *MethodErr) Patch() error {
+	return ErrMethodNotAllowed
+}
+
+func (me *MethodErr) Options() error {
+	return ErrMethodNotAllowed
+}
+
+func (me *MethodErr) Finish() {
+	// Do nothing
+}
+
+func (me *MethodErr) getType() reflect.Type {
+	me._s.RLock()
+	defer me._s.RUnlock()
+	return me._t
+}
+
+func (me *MethodErr) setType(t reflect.Type) {
+	me._s.Lock()
+	defer me._s.Unlock()
+	me._t = t
+}
+
+// Assert Serial Number
+func (me *MethodErr) asn_Core_0003() {
+	// Do nothing
+}
+
+func (_ *MethodErr) init(ro RouteHandler) {
+	me := ro.(MethodErrInterface)
+	t := me.getType()
+	if t == nil {
+		t = reflect.Indirect(reflect.ValueOf(me)).Type()
+		me.setType(t)
+	}
+}
+
+// Alais of MethodErr
+type VerbErr struct {
+	MethodErr
+}
@@ -0,0 +1,115 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MethodErrDummy struct {
+	MethodErr
+}
+
+func (me *MethodErrDummy) Get() error {
+	me.C.Pub.Group.Set("method", "GET")
+	return nil
+}
+
+func (me *MethodErrDummy) Post() error {
+	return ErrBadRequest("invalid").WithDetail("title", "required")
+}
+
+func (me *MethodErrDummy) Delete() error {
+	return errors.New("internal")
+}
+
+func TestMethodErr(t *testing.T) {
+	App := NewApp()
+
+	App.Debug = true
+
+	panicHandler := DefaultPanicHandler
+	defer func() {
+		DefaultPanicHandler = panicHandler
+	}()
+
+	logged := []interface{}{}
+	DefaultPanicHandler = panicFunc(func(c *Context, r interface{}, stack []byte) {
+		logged = append(logged, r)
+	})
+
+	App.TestView = RouteHandlerFunc(func(c *Context) {
+		status := func() int {
+			return c.Pub.Status
+		}
+
+		c.RouteDealer(&MethodErrDummy{})
+
+		if status() != 200 || c.Pub.Group.Get("method") != "GET" {
+			t.Fail()
+		}
+
+		c.Pub.Errors.Register(Error4xx, func(c *Context, err error) {
+			httpErr := err.(*HTTPError)
+			if c.Pub.Status == 400 && httpErr.Details["title"] != "required" {
+				t.Fail()
+			}
+		})
+
+		c.Pub.Errors.E500 = func(c *Context) {
+			// Do nothing
+		}
+
+		c.Req.Method = "POST"
+		c.pri.cut = false
+		c.RouteDealer(&MethodErrDummy{})
+
+		if status() != 400 || len(logged) != 0 {
+			t.Fail()
+		}
+
+		c.Req.Method = "PUT"
+		c.pri.cut = false
+		c.RouteDealer(&MethodErrDummy{})
+
+		if status() != 405 {
+			t.Fail()
+		}
+
+		c.Req.Method = "DELETE"
+		c.pri.cut = false
+		c.RouteDealer(&MethodErrDummy{})
+
+		if status() != 500 || len(logged) != 1 {
+			t.Fail()
+		}
+	})
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	http.Get(ts.URL)
+}
+
+type panicFunc func(*Context, interface{}, []byte)
+
+func (fn panicFunc) Panic(c *Context, r interface{}, stack []byte) {
+	fn(c, r, stack)
+}
+
+func TestHTTPErrorWithCopies(t *testing.T) {
+	err := ErrMethodNotAllowed.WithDetail("allow", "GET").WithCause(errors.New("internal"))
+
+	if ErrMethodNotAllowed.Details != nil || ErrMethodNotAllowed.Cause != nil {
+		t.Fail()
+	}
+
+	if err.Details["allow"] != "GET" || err.Cause == nil || err.Status != 405 {
+		t.Fail()
+	}
+
+	if err.WithDetail("extra", 1); len(err.Details) != 1 {
+		t.Fail()
+	}
+}
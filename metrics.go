@@ -0,0 +1,182 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Default Latency Histogram Buckets in seconds
+var MetricsBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+type routeMetrics struct {
+	requests map[[2]string]uint64
+	buckets  []uint64
+	sum      float64
+	count    uint64
+}
+
+// Request Metrics, exposed in Prometheus text format. Implement RouteHandler interface.
+type Metrics struct {
+	sync.Mutex
+	app      *App
+	buckets  []float64
+	routes   map[string]*routeMetrics
+	inFlight int64
+}
+
+// Construct New Metrics, uses MetricsBuckets if buckets is nil.
+func NewMetrics(app *App, buckets []float64) *Metrics {
+	if buckets == nil {
+		buckets = MetricsBuckets
+	}
+	return &Metrics{app: app, buckets: buckets, routes: map[string]*routeMetrics{}}
+}
+
+// Get Metrics, only collected if App.MetricsEnabled is true.
+func (app *App) Metrics() *Metrics {
+	return app.metrics
+}
+
+func (m *Metrics) begin() time.Time {
+	atomic.AddInt64(&m.inFlight, 1)
+	return time.Now()
+}
+
+func (m *Metrics) observe(c *Context, start time.Time) {
+	atomic.AddInt64(&m.inFlight, -1)
+
+	duration := time.Since(start).Seconds()
+	route := c.RoutePattern()
+	switch {
+	case c.pri.unmatched:
+		// Keep 404 noise apart from the routes
+		route = "unmatched"
+	case route == "":
+		route = "/"
+	}
+	class := fmt.Sprint(c.Pub.Status/100, "xx")
+
+	m.Lock()
+	defer m.Unlock()
+
+	rm := m.routes[route]
+	if rm == nil {
+		rm = &routeMetrics{requests: map[[2]string]uint64{}, buckets: make([]uint64, len(m.buckets))}
+		m.routes[route] = rm
+	}
+
+	rm.requests[[2]string{c.Req.Method, class}]++
+	for i, bound := range m.buckets {
+		if duration <= bound {
+			rm.buckets[i]++
+		}
+	}
+	rm.sum += duration
+	rm.count++
+}
+
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatFloat(f float64) string {
+	return fmt.Sprint(f)
+}
+
+// Write Metrics in Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	buf := &bytes.Buffer{}
+
+	m.Lock()
+
+	routes := []string{}
+	for route := range m.routes {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+
+	fmt.Fprint(buf, "# HELP core_http_requests_total Total number of HTTP requests by route, method and status class.\n")
+	fmt.Fprint(buf, "# TYPE core_http_requests_total counter\n")
+	for _, route := range routes {
+		rm := m.routes[route]
+		keys := [][2]string{}
+		for key := range rm.requests {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return keys[i][0]+keys[i][1] < keys[j][0]+keys[j][1]
+		})
+		for _, key := range keys {
+			fmt.Fprintf(buf, "core_http_requests_total{route=\"%s\",method=\"%s\",code=\"%s\"} %d\n",
+				escapeLabel(route), escapeLabel(key[0]), key[1], rm.requests[key])
+		}
+	}
+
+	fmt.Fprint(buf, "# HELP core_http_request_duration_seconds HTTP request latency by route.\n")
+	fmt.Fprint(buf, "# TYPE core_http_request_duration_seconds histogram\n")
+	for _, route := range routes {
+		rm := m.routes[route]
+		label := escapeLabel(route)
+		for i, bound := range m.buckets {
+			fmt.Fprintf(buf, "core_http_request_duration_seconds_bucket{route=\"%s\",le=\"%s\"} %d\n",
+				label, formatFloat(bound), rm.buckets[i])
+		}
+		fmt.Fprintf(buf, "core_http_request_duration_seconds_bucket{route=\"%s\",le=\"+Inf\"} %d\n", label, rm.count)
+		fmt.Fprintf(buf, "core_http_request_duration_seconds_sum{route=\"%s\"} %s\n", label, formatFloat(rm.sum))
+		fmt.Fprintf(buf, "core_http_request_duration_seconds_count{route=\"%s\"} %d\n", label, rm.count)
+	}
+
+	m.Unlock()
+
+	fmt.Fprint(buf, "# HELP core_http_requests_in_flight Number of HTTP requests being served.\n")
+	fmt.Fprint(buf, "# TYPE core_http_requests_in_flight gauge\n")
+	fmt.Fprintf(buf, "core_http_requests_in_flight %d\n", atomic.LoadInt64(&m.inFlight))
+
+	m.app.sessionMapSync.Lock()
+	sessions := len(m.app.sessionMap)
+	m.app.sessionMapSync.Unlock()
+
+	fmt.Fprint(buf, "# HELP core_sessions Number of sessions held in memory (SessionMemory).\n")
+	fmt.Fprint(buf, "# TYPE core_sessions gauge\n")
+	fmt.Fprintf(buf, "core_sessions %d\n", sessions)
+
+	mem := runtime.MemStats{}
+	runtime.ReadMemStats(&mem)
+
+	gauges := []struct {
+		name, help string
+		value      interface{}
+	}{
+		{"go_goroutines", "Number of goroutines.", runtime.NumGoroutine()},
+		{"go_memstats_alloc_bytes", "Bytes of allocated heap objects.", mem.Alloc},
+		{"go_memstats_sys_bytes", "Bytes of memory obtained from the OS.", mem.Sys},
+		{"go_memstats_heap_objects", "Number of allocated heap objects.", mem.HeapObjects},
+	}
+	for _, g := range gauges {
+		fmt.Fprintf(buf, "# HELP %s %s\n# TYPE %s gauge\n%s %v\n", g.name, g.help, g.name, g.name, g.value)
+	}
+
+	fmt.Fprint(buf, "# HELP go_gc_cycles_total Number of completed GC cycles.\n")
+	fmt.Fprint(buf, "# TYPE go_gc_cycles_total counter\n")
+	fmt.Fprintf(buf, "go_gc_cycles_total %d\n", mem.NumGC)
+
+	fmt.Fprint(buf, "# HELP go_gc_pause_seconds_total Total GC stop-the-world pause time.\n")
+	fmt.Fprint(buf, "# TYPE go_gc_pause_seconds_total counter\n")
+	fmt.Fprintf(buf, "go_gc_pause_seconds_total %s\n", formatFloat(time.Duration(mem.PauseTotalNs).Seconds()))
+
+	return buf.WriteTo(w)
+}
+
+// Implement RouteHandler, e.g. app.DirRouter("main").Register("metrics", app.Metrics())
+func (m *Metrics) View(c *Context) {
+	c.Res.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	c.Res.Header().Set("Cache-Control", "no-store")
+	m.WriteTo(c.Res)
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMetrics(t *testing.T) {
+	App := NewApp()
+
+	App.MetricsEnabled = true
+
+	App.DefaultRouter = App.DirRouter("main").RootFunc(func(c *Context) {
+		c.Fmt().Print("Hello World")
+	}).Register("metrics", App.Metrics()).Register("blog", NewDirRouter().AsteriskFunc(func(c *Context) {
+		c.Error404()
+	}))
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	for _, path := range []string{"/", "/", "/blog/a", "/blog/b", "/missing"} {
+		res, err := http.Get(ts.URL + path)
+		Check(err)
+		res.Body.Close()
+	}
+
+	res, err := http.Get(ts.URL + "/metrics")
+	Check(err)
+	b, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	body := string(b)
+
+	expected := []string{
+		`core_http_requests_total{route="/",method="GET",code="2xx"} 2`,
+		`core_http_requests_total{route="unmatched",method="GET",code="4xx"} 1`,
+		`core_http_requests_total{route="/blog/*",method="GET",code="4xx"} 2`,
+		`core_http_request_duration_seconds_count{route="/"} 2`,
+		`core_http_request_duration_seconds_bucket{route="/blog/*",le="+Inf"} 2`,
+		`core_http_requests_in_flight 1`,
+		`core_sessions 0`,
+		`# TYPE go_goroutines gauge`,
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(body, line+"\n") {
+			t.Error("missing:", line)
+		}
+	}
+}
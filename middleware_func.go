@@ -0,0 +1,102 @@
+package core
+
+import (
+	"sync"
+)
+
+// Function Middleware, call next to continue down the chain, skip it to stop.
+type MiddlewareFunc func(c *Context, next func())
+
+type middlewareFuncs []MiddlewareFunc
+
+// Execute middlewares in order and than fn.
+func (mf middlewareFuncs) run(c *Context, fn func()) {
+	var call func(i int)
+	call = func(i int) {
+		if i == len(mf) {
+			fn()
+			return
+		}
+		mf[i](c, func() {
+			call(i + 1)
+		})
+	}
+	call(0)
+}
+
+// Scoped Middlewares, embedded in Router, DirRouter, VHost and VHostRegExp
+type middlewareScope struct {
+	_m  sync.RWMutex
+	fns middlewareFuncs
+}
+
+func (ms *middlewareScope) use(fns ...MiddlewareFunc) {
+	ms._m.Lock()
+	defer ms._m.Unlock()
+	ms.fns = append(ms.fns, fns...)
+}
+
+func (ms *middlewareScope) run(c *Context, fn func()) {
+	ms._m.RLock()
+	fns := ms.fns
+	ms._m.RUnlock()
+	fns.run(c, fn)
+}
+
+// Convert Middlewares to MiddlewareFunc, Pre and Post are executed around next.
+func (mid *Middlewares) Func() MiddlewareFunc {
+	return func(c *Context, next func()) {
+		middlewares := mid.Init(c)
+		defer middlewares.Post()
+		middlewares.Pre()
+		if c.Terminated() {
+			return
+		}
+		next()
+	}
+}
+
+type middlewareRoute struct {
+	RouteHandler
+	fns middlewareFuncs
+}
+
+func (mr middlewareRoute) View(c *Context) {
+	mr.fns.run(c, func() {
+		c.RouteDealer(mr.RouteHandler)
+	})
+}
+
+// Wrap Route Handler with Middlewares, executed only when the route is matched.
+func WithMiddleware(handler RouteHandler, fns ...MiddlewareFunc) RouteHandler {
+	return middlewareRoute{handler, middlewareFuncs(fns)}
+}
+
+// Use Middlewares on every request, executed after "main" and before DefaultView.
+func (app *App) Use(fns ...MiddlewareFunc) {
+	app.middlewareScope.use(fns...)
+}
+
+// Use Middlewares, executed when a route of the Router is matched.
+func (ro *Router) Use(fns ...MiddlewareFunc) *Router {
+	ro.middlewareScope.use(fns...)
+	return ro
+}
+
+// Use Middlewares, executed when a directory (root, named or asterisk) of the DirRouter is matched.
+func (dir *DirRouter) Use(fns ...MiddlewareFunc) *DirRouter {
+	dir.middlewareScope.use(fns...)
+	return dir
+}
+
+// Use Middlewares, executed when a host of the VHost is matched.
+func (v *VHost) Use(fns ...MiddlewareFunc) *VHost {
+	v.middlewareScope.use(fns...)
+	return v
+}
+
+// Use Middlewares, executed when a host of the VHostRegExp is matched.
+func (vh *VHostRegExp) Use(fns ...MiddlewareFunc) *VHostRegExp {
+	vh.middlewareScope.use(fns...)
+	return vh
+}
@@ -1,79 +1,140 @@
-package core
-
-import (
-	"net/http"
-	"net/http/httptest"
-	"testing"
-)
-
-type MiddlewareDummy struct {
-	Middleware
-}
-
-func (mid *MiddlewareDummy) Pre() {
-	mid.C.Pub.Group.Set("result", "PRE")
-}
-
-func (mid *MiddlewareDummy) Post() {
-	mid.C.Pub.Group.Set("result", "POST")
-}
-
-type MiddlewareDummy2 struct {
-	Middleware
-}
-
-func (mid *MiddlewareDummy2) Priority() int {
-	return 5
-}
-
-func (mid *MiddlewareDummy2) Pre() {
-	mid.C.Pub.Group.Set("result", "PRE2")
-	mid.C.Terminate()
-}
-
-func (mid *MiddlewareDummy2) Post() {
-	mid.C.Pub.Group.Set("result", "POST2")
-}
-
-func TestMiddleware(t *testing.T) {
-	App := NewApp()
-
-	App.Debug = true
-
-	App.TestView = RouteHandlerFunc(func(c *Context) {
-		result := func() string {
-			return c.Pub.Group.Get("result")
-		}
-
-		mid := NewMiddlewares().Register(&MiddlewareDummy{}).Init(c)
-		mid.Pre()
-
-		if result() != "PRE" {
-			t.Fail()
-		}
-
-		mid.Post()
-
-		if result() != "POST" {
-			t.Fail()
-		}
-
-		mid = NewMiddlewares().Register(&MiddlewareDummy2{}, &MiddlewareDummy{}).Init(c)
-		mid.Pre()
-
-		if result() != "PRE2" {
-			t.Fail()
-		}
-
-		mid.Post()
-
-		if result() != "POST2" {
-			t.Fail()
-		}
-	})
-
-	ts := httptest.NewServer(App)
-	defer ts.Close()
-
-	http.Get(ts.URL)
-}
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type MiddlewareDummy struct {
+	Middleware
+}
+
+func (mid *MiddlewareDummy) Pre() {
+	mid.C.Pub.Group.Set("result", "PRE")
+}
+
+func (mid *MiddlewareDummy) Post() {
+	mid.C.Pub.Group.Set("result", "POST")
+}
+
+type MiddlewareDummy2 struct {
+	Middleware
+}
+
+func (mid *MiddlewareDummy2) Priority() int {
+	return 5
+}
+
+func (mid *MiddlewareDummy2) Pre() {
+	mid.C.Pub.Group.Set("result", "PRE2")
+	mid.C.Terminate()
+}
+
+func (mid *MiddlewareDummy2) Post() {
+	mid.C.Pub.Group.Set("result", "POST2")
+}
+
+func TestMiddleware(t *testing.T) {
+	App := NewApp()
+
+	App.Debug = true
+
+	App.TestView = RouteHandlerFunc(func(c *Context) {
+		result := func() string {
+			return c.Pub.Group.Get("result")
+		}
+
+		mid := NewMiddlewares().Register(&MiddlewareDummy{}).Init(c)
+		mid.Pre()
+
+		if result() != "PRE" {
+			t.Fail()
+		}
+
+		mid.Post()
+
+		if result() != "POST" {
+			t.Fail()
+		}
+
+		mid = NewMiddlewares().Register(&MiddlewareDummy2{}, &MiddlewareDummy{}).Init(c)
+		mid.Pre()
+
+		if result() != "PRE2" {
+			t.Fail()
+		}
+
+		mid.Post()
+
+		if result() != "POST2" {
+			t.Fail()
+		}
+	})
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	http.Get(ts.URL)
+}
+
+func TestMiddlewareScope(t *testing.T) {
+	App := NewApp()
+
+	order := ""
+
+	mark := func(s string) MiddlewareFunc {
+		return func(c *Context, next func()) {
+			order += s
+			next()
+		}
+	}
+
+	stop := func(c *Context, next func()) {
+		c.Error403()
+	}
+
+	post := func(c *Context, next func()) {
+		next()
+		order += "-" + c.Pub.Group.Get("result")
+	}
+
+	App.Use(mark("A"))
+
+	App.DefaultRouter = NewDirRouter().RootDir(RouteHandlerFunc(func(c *Context) {
+		order += "R"
+		c.Fmt().Print("root")
+	})).Register("admin", NewDirRouter().Use(mark("D"), stop).RootDir(RouteHandlerFunc(func(c *Context) {
+		t.Fail()
+	}))).Register("page", WithMiddleware(RouteHandlerFunc(func(c *Context) {
+		order += "P-" + c.Pub.Group.Get("result")
+		c.Fmt().Print("page")
+	}), mark("W"), post, NewMiddlewares().Register(&MiddlewareDummy{}).Func()))
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	res, _ := http.Get(ts.URL)
+	res.Body.Close()
+
+	if order != "AR" {
+		t.Fail()
+	}
+
+	order = ""
+	res, _ = http.Get(ts.URL + "/admin")
+	res.Body.Close()
+
+	if order != "AD" || res.StatusCode != 403 {
+		t.Fail()
+	}
+
+	order = ""
+	res, _ = http.Get(ts.URL + "/page")
+	res.Body.Close()
+
+	// Middlewares.Func runs Pre before and Post after the route
+	if order != "AWP-PRE-POST" || res.StatusCode != 200 {
+		t.Fail()
+	}
+}
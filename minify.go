@@ -0,0 +1,413 @@
+package core
+
+import (
+	"bytes"
+	"io"
+	"strings"
+)
+
+const (
+	minifyText = iota
+	minifyTag
+	minifyComment
+	minifyRaw
+)
+
+// Streaming HTML Minifier, collapses whitespace and removes comments.
+// Content of pre and textarea is kept as is, inline script and style are minified conservatively.
+// Conditional comments (<!--[if ...]>) are kept. Close must be called to flush.
+type HtmlMinifier struct {
+	w       io.Writer
+	out     bytes.Buffer
+	state   int
+	tag     bytes.Buffer
+	raw     bytes.Buffer
+	rawTag  string
+	rawOpen string
+	quote   byte
+	space   bool
+	started bool
+	err     error
+}
+
+// Construct New HTML Minifier writing to w
+func NewHtmlMinifier(w io.Writer) *HtmlMinifier {
+	return &HtmlMinifier{w: w}
+}
+
+func minifySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func minifyLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// Tag name in lower case, prefixed with "/" for end tags
+func minifyTagName(tag string) string {
+	name := strings.TrimPrefix(tag, "<")
+	end := 0
+	for end < len(name) && (minifyLetter(name[end]) || name[end] >= '0' && name[end] <= '9' || name[end] == '-' || end == 0 && name[end] == '/') {
+		end++
+	}
+	return strings.ToLower(name[:end])
+}
+
+// Implement io.Writer
+func (m *HtmlMinifier) Write(p []byte) (int, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	for _, c := range p {
+		m.byte(c)
+	}
+	if m.out.Len() > 0 {
+		m.started = true
+		_, m.err = m.out.WriteTo(m.w)
+	}
+	return len(p), m.err
+}
+
+func (m *HtmlMinifier) byte(c byte) {
+	switch m.state {
+	case minifyText:
+		switch {
+		case c == '<':
+			m.state = minifyTag
+			m.tag.Reset()
+			m.tag.WriteByte(c)
+		case minifySpace(c):
+			m.space = true
+		default:
+			m.flushSpace()
+			m.out.WriteByte(c)
+		}
+
+	case minifyTag:
+		if m.tag.Len() == 1 && !minifyLetter(c) && c != '/' && c != '!' && c != '?' {
+			// "a < b" is text
+			m.state = minifyText
+			m.flushSpace()
+			m.out.WriteByte('<')
+			m.byte(c)
+			return
+		}
+
+		switch {
+		case m.quote != 0:
+			if c == m.quote {
+				m.quote = 0
+			}
+			m.tag.WriteByte(c)
+			return
+		case c == '"' || c == '\'':
+			m.quote = c
+		case minifySpace(c):
+			if b := m.tag.Bytes(); b[len(b)-1] != ' ' {
+				m.tag.WriteByte(' ')
+			}
+			return
+		}
+
+		if c == '>' {
+			tag := strings.TrimSuffix(m.tag.String(), " ")
+			if strings.HasSuffix(tag, " /") {
+				tag = tag[:len(tag)-2] + "/"
+			}
+			m.tag.Reset()
+			m.flushSpace()
+			m.out.WriteString(tag + ">")
+			m.state = minifyText
+			switch name := minifyTagName(tag); name {
+			case "pre", "textarea", "script", "style":
+				if !strings.HasSuffix(tag, "/") {
+					m.state, m.rawTag, m.rawOpen = minifyRaw, name, tag
+					m.raw.Reset()
+				}
+			}
+			return
+		}
+
+		m.tag.WriteByte(c)
+		if m.tag.String() == "<!--" {
+			m.tag.Reset()
+			m.state = minifyComment
+		}
+
+	case minifyComment:
+		m.tag.WriteByte(c)
+		if c == '>' && bytes.HasSuffix(m.tag.Bytes(), []byte("-->")) {
+			if bytes.HasPrefix(m.tag.Bytes(), []byte("[if")) || bytes.HasPrefix(m.tag.Bytes(), []byte("<![endif]")) {
+				m.flushSpace()
+				m.out.WriteString("<!--")
+				m.out.Write(m.tag.Bytes())
+			}
+			m.tag.Reset()
+			m.state = minifyText
+		}
+
+	case minifyRaw:
+		m.raw.WriteByte(c)
+		end := "</" + m.rawTag
+		if b := m.raw.Bytes(); len(b) >= len(end) && strings.EqualFold(string(b[len(b)-len(end):]), end) {
+			content := string(b[:len(b)-len(end)])
+			switch m.rawTag {
+			case "script":
+				if minifyScriptType(m.rawOpen) {
+					content = MinifyJs(content)
+				}
+			case "style":
+				content = MinifyCss(content)
+			}
+			m.out.WriteString(content)
+			m.raw.Reset()
+			m.space = false
+			m.state = minifyTag
+			m.tag.Reset()
+			m.tag.WriteString(end)
+		}
+	}
+}
+
+// Pending whitespace becomes one space, leading whitespace is dropped.
+func (m *HtmlMinifier) flushSpace() {
+	if m.space && (m.started || m.out.Len() > 0) {
+		m.out.WriteByte(' ')
+	}
+	m.space = false
+}
+
+// Only JavaScript is minified, e.g. not application/ld+json or text/template.
+func minifyScriptType(tag string) bool {
+	lower := strings.ToLower(tag)
+	pos := strings.Index(lower, "type=")
+	if pos == -1 {
+		return true
+	}
+	ctype := strings.Trim(strings.SplitN(lower[pos+len("type="):], " ", 2)[0], `"'>/`)
+	return ctype == "" || ctype == "module" || strings.Contains(ctype, "javascript") || strings.Contains(ctype, "ecmascript")
+}
+
+// Write unfinished input as is and flush
+func (m *HtmlMinifier) Close() error {
+	if m.err != nil {
+		return m.err
+	}
+	switch m.state {
+	case minifyTag:
+		m.out.Write(m.tag.Bytes())
+	case minifyComment:
+		m.out.WriteString("<!--")
+		m.out.Write(m.tag.Bytes())
+	case minifyRaw:
+		m.out.Write(m.raw.Bytes())
+	}
+	m.tag.Reset()
+	m.raw.Reset()
+	m.state = minifyText
+	if m.out.Len() > 0 {
+		_, m.err = m.out.WriteTo(m.w)
+	}
+	return m.err
+}
+
+// Minify HTML
+func MinifyHtml(html string) string {
+	buf := &bytes.Buffer{}
+	m := NewHtmlMinifier(buf)
+	m.Write([]byte(html))
+	m.Close()
+	return buf.String()
+}
+
+// Copy string literal starting at s[i] to out, returns index after it.
+func minifyString(s string, i int, out *strings.Builder) int {
+	quote := s[i]
+	out.WriteByte(quote)
+	for i++; i < len(s); i++ {
+		out.WriteByte(s[i])
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+			out.WriteByte(s[i])
+			continue
+		}
+		if s[i] == quote {
+			return i + 1
+		}
+	}
+	return i
+}
+
+// Minify CSS conservatively, removes comments and whitespace around { } ; , and the last ; of a block.
+func MinifyCss(css string) string {
+	out := &bytes.Buffer{}
+	space := false
+	last := byte(0)
+
+	for i := 0; i < len(css); {
+		c := css[i]
+		switch {
+		case c == '/' && i+1 < len(css) && css[i+1] == '*':
+			end := strings.Index(css[i+2:], "*/")
+			if end == -1 {
+				return out.String()
+			}
+			i += end + 4
+			space = true
+			continue
+		case minifySpace(c):
+			space = true
+			i++
+			continue
+		case strings.IndexByte("{};,", c) != -1:
+			if c == '}' && last == ';' {
+				out.Truncate(out.Len() - 1)
+			}
+		case space && last != 0 && strings.IndexByte("{};,", last) == -1:
+			out.WriteByte(' ')
+		}
+		space = false
+
+		if c == '"' || c == '\'' {
+			str := &strings.Builder{}
+			i = minifyString(css, i, str)
+			out.WriteString(str.String())
+			last = css[i-1]
+			continue
+		}
+		out.WriteByte(c)
+		last = c
+		i++
+	}
+	return out.String()
+}
+
+// Keywords after which "/" starts a regular expression
+var minifyJsRegexKeywords = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "in": true, "of": true, "new": true,
+	"delete": true, "void": true, "throw": true, "case": true, "do": true, "else": true,
+	"yield": true, "await": true,
+}
+
+func minifyJsIdent(c byte) bool {
+	return minifyLetter(c) || c >= '0' && c <= '9' || c == '_' || c == '$' || c >= 0x80
+}
+
+// Does "/" after out start a regular expression, decided by the previous token.
+// Ambiguous cases (after "}", ")", "++" or "--") are reported as such.
+func minifyJsRegexAllowed(out string) (regex, ambiguous bool) {
+	if out == "" {
+		return true, false
+	}
+	last := out[len(out)-1]
+	switch {
+	// ")" ends an expression or an if, while or for head: "(a) / 2" or "if (a) /re/.test(s)"
+	case last == '}' || last == ')' || strings.HasSuffix(out, "++") || strings.HasSuffix(out, "--"):
+		return false, true
+	case last == ']' || last == '"' || last == '\'' || last == '`':
+		return false, false
+	case minifyJsIdent(last):
+		start := len(out)
+		for start > 0 && minifyJsIdent(out[start-1]) {
+			start--
+		}
+		return minifyJsRegexKeywords[out[start:]], false
+	}
+	return true, false
+}
+
+// Copy regular expression literal starting at s[i] to out, returns index after it or -1 if unterminated.
+func minifyJsRegex(s string, i int, out *strings.Builder) int {
+	class := false
+	for j := i + 1; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '\n', '\r':
+			return -1
+		case '[':
+			class = true
+		case ']':
+			class = false
+		case '/':
+			if !class {
+				out.WriteString(s[i : j+1])
+				return j + 1
+			}
+		}
+	}
+	return -1
+}
+
+// Minify JavaScript conservatively, removes comments (except /*! ... */), indentation and blank lines.
+// Line breaks are kept for automatic semicolon insertion.
+// Returned unchanged if a "/" can not be told apart as division or regular expression.
+func MinifyJs(js string) string {
+	out := &strings.Builder{}
+	space, newline := false, false
+
+	pending := func() {
+		switch {
+		case newline && out.Len() > 0:
+			out.WriteByte('\n')
+		case space && out.Len() > 0:
+			out.WriteByte(' ')
+		}
+		space, newline = false, false
+	}
+
+	for i := 0; i < len(js); {
+		c := js[i]
+		switch {
+		case c == '"' || c == '\'' || c == '`':
+			pending()
+			i = minifyString(js, i, out)
+			continue
+		case c == '/' && i+1 < len(js) && js[i+1] == '*':
+			end := strings.Index(js[i+2:], "*/")
+			if end == -1 {
+				return js
+			}
+			if i+2 < len(js) && js[i+2] == '!' {
+				pending()
+				out.WriteString(js[i : i+end+4])
+			} else if strings.Contains(js[i:i+end+4], "\n") {
+				newline = true
+			} else {
+				space = true
+			}
+			i += end + 4
+			continue
+		case c == '/' && i+1 < len(js) && js[i+1] == '/':
+			end := strings.IndexByte(js[i:], '\n')
+			if end == -1 {
+				return out.String()
+			}
+			i += end
+			continue
+		case c == '/':
+			regex, ambiguous := minifyJsRegexAllowed(out.String())
+			if ambiguous {
+				return js
+			}
+			if regex {
+				pending()
+				if i = minifyJsRegex(js, i, out); i == -1 {
+					return js
+				}
+				continue
+			}
+			pending()
+			out.WriteByte(c)
+		case c == '\n' || c == '\r':
+			newline = true
+		case minifySpace(c):
+			space = true
+		default:
+			pending()
+			out.WriteByte(c)
+		}
+		i++
+	}
+	return out.String()
+}
@@ -0,0 +1,138 @@
+package core
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMinifyHtml(t *testing.T) {
+	in := `
+<!DOCTYPE html>
+<html>
+  <!-- removed -->
+  <!--[if IE]><p>IE</p><![endif]-->
+  <body   class="a  b" >
+    <p>Hello
+       world</p>
+    <pre>  keep
+    this  </pre>
+    <textarea>  a
+  b</textarea>
+    <p>1 < 2</p>
+    <br />
+  </body>
+</html>
+`
+	expected := `<!DOCTYPE html> <html> <!--[if IE]><p>IE</p><![endif]--> <body class="a  b"> <p>Hello world</p> <pre>  keep
+    this  </pre> <textarea>  a
+  b</textarea> <p>1 < 2</p> <br/> </body> </html>`
+
+	if out := MinifyHtml(in); out != expected {
+		t.Errorf("%q", out)
+	}
+}
+
+func TestMinifyHtmlStreaming(t *testing.T) {
+	in := "<p>  split   across\n writes </p>\n<pre> a  b </pre>"
+	expected := MinifyHtml(in)
+
+	res := httptest.NewRecorder()
+	m := NewHtmlMinifier(res)
+	for i := 0; i < len(in); i++ {
+		m.Write([]byte{in[i]})
+	}
+	m.Close()
+
+	if res.Body.String() != expected {
+		t.Errorf("%q", res.Body.String())
+	}
+}
+
+func TestMinifyScriptStyle(t *testing.T) {
+	in := `<script>
+  // comment
+  var a = "  spaced  " // trailing
+  /* block */
+  var url = "http://example.com"
+  f(a)
+</script><script type="application/ld+json">
+  { "a":  1 }
+</script><style>
+  /* comment */
+  body  {
+    color : red ;
+    font-family: "A  B", serif;
+  }
+</style>`
+	expected := `<script>var a = "  spaced  "
+var url = "http://example.com"
+f(a)</script><script type="application/ld+json">
+  { "a":  1 }
+</script><style>body{color : red;font-family: "A  B",serif}</style>`
+
+	if out := MinifyHtml(in); out != expected {
+		t.Errorf("%q", out)
+	}
+
+	if MinifyJs("/*! licence */\nx = 1") != "/*! licence */\nx = 1" {
+		t.Fail()
+	}
+}
+
+func TestMinifyJsRegex(t *testing.T) {
+	for in, expected := range map[string]string{
+		// Quotes inside regular expressions do not start strings
+		"s.replace(/'/g, \"\");\nvar u = 'http://x.com/  y';": "s.replace(/'/g, \"\");\nvar u = 'http://x.com/  y';",
+		"var re = /[/\"]+/ // comment\nx  =  1":               "var re = /[/\"]+/\nx = 1",
+		"return /a b/.test(s)":                                "return /a b/.test(s)",
+		// Division
+		"var half = total / 2 // comment": "var half = total / 2",
+		"var r = a[0] / 2;  /* x */ y":    "var r = a[0] / 2; y",
+		// Ambiguous or unterminated input is kept as is
+		"if (x) {}\n/  a/.test(s)":        "if (x) {}\n/  a/.test(s)",
+		"if (a) /re  x/.test(s)":          "if (a) /re  x/.test(s)",
+		"var r = (a + b) / 2;  /* x */ y": "var r = (a + b) / 2;  /* x */ y",
+		"x = /abc\n  y":                   "x = /abc\n  y",
+	} {
+		if out := MinifyJs(in); out != expected {
+			t.Errorf("%q: %q", in, out)
+		}
+	}
+}
+
+type MethodHtml5MinifyDummy struct {
+	MethodHtml5
+}
+
+func (me *MethodHtml5MinifyDummy) Get() {
+	if me.C.Req.URL.Query().Get("minify") == "off" {
+		me.Minify(false)
+	}
+	me.BodyContent("<p>\n  Hello\n  world\n</p>")
+}
+
+func TestMethodHtml5Minify(t *testing.T) {
+	App := NewApp()
+	App.HtmlMinify = true
+	App.DefaultRouter = NewDirRouter().Register("page", &MethodHtml5MinifyDummy{})
+
+	get := func(url string) string {
+		res := httptest.NewRecorder()
+		App.ServeHTTP(res, httptest.NewRequest("GET", url, nil))
+		return res.Body.String()
+	}
+
+	minified := "<!DOCTYPE html> <html> <head> <title></title> </head> <body> <p> Hello world </p> </body> </html>"
+	if out := get("/page"); out != minified {
+		t.Errorf("%q", out)
+	}
+
+	if out := get("/page?minify=off"); out == minified {
+		t.Fail()
+	}
+
+	App.Debug = true
+	if out := get("/page"); out == minified {
+		t.Fail()
+	}
+}
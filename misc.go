@@ -1,105 +1,134 @@
-package core
-
-import (
-	"io/ioutil"
-	"net"
-	"strconv"
-	"strings"
-	"sync"
-)
-
-// Convert String to int64
-func toInt(number string) (int64, error) {
-	return strconv.ParseInt(number, 10, 64)
-}
-
-// Convert String to Uint64
-func toUint(number string) (uint64, error) {
-	return strconv.ParseUint(number, 10, 64)
-}
-
-// Convert String to float64
-func toFloat(number string) (float64, error) {
-	return strconv.ParseFloat(number, 64)
-}
-
-func (c *Context) initWriter() {
-	if c.Req.Method == "HEAD" {
-		c.pri.reswrite = ioutil.Discard
-		c.Res.Header().Set("Connection", "close")
-		return
-	}
-	c.pri.reswrite = c.Res.rw
-	c.Res.Header().Set("Content-Encoding", "plain")
-
-}
-
-func (c *Context) initTrueHost() {
-	switch {
-	case c.Req.Header.Get("Host") != "":
-		c.Req.Host = c.Req.Header.Get("Host")
-	case c.Req.Header.Get("X-Forwarded-Host") != "":
-		c.Req.Host = c.Req.Header.Get("X-Forwarded-Host")
-	case c.Req.Header.Get("X-Forwarded-Server") != "":
-		c.Req.Host = c.Req.Header.Get("X-Forwarded-Server")
-	}
-	c.Req.URL.Host = c.Req.Host
-}
-
-func (c *Context) initTrueRemoteAddr() {
-	address := ""
-
-	switch {
-	case c.Req.Header.Get("X-Real-Ip") != "":
-		address = c.Req.Header.Get("X-Real-Ip")
-	case c.Req.Header.Get("X-Forwarded-For") != "":
-		address = c.Req.Header.Get("X-Forwarded-For")
-	}
-
-	if address != "" {
-		c.Req.RemoteAddr = net.JoinHostPort(strings.Trim(address, "[]"), "1234")
-	}
-}
-
-func (c *Context) initTruePath() {
-	switch {
-	case c.Req.Header.Get("X-Original-Url") != "":
-		// For compatibility with IIS
-		urls := strings.Split(c.Req.Header.Get("X-Original-Url"), "?")
-		c.Req.URL.Path = urls[0]
-		c.pri.path = c.Req.URL.Path
-
-		if len(urls) < 2 {
-			return
-		}
-
-		c.Req.URL.RawQuery = strings.Join(urls[1:], "?")
-	}
-}
-
-// Get Remote Address (IP Address) without port number!
-func (c *Context) RemoteAddr() string {
-	ip, _, _ := net.SplitHostPort(c.Req.RemoteAddr)
-	return ip
-}
-
-type AtomicString struct {
-	sync.Mutex
-	s string
-}
-
-func NewAtomicString(s string) *AtomicString {
-	return &AtomicString{s: s}
-}
-
-func (str *AtomicString) String() string {
-	str.Lock()
-	defer str.Unlock()
-	return str.s
-}
-
-func (str *AtomicString) Set(s string) {
-	str.Lock()
-	defer str.Unlock()
-	str.s = s
-}
+package core
+
+import (
+	"io/ioutil"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Convert String to int64
+func toInt(number string) (int64, error) {
+	return strconv.ParseInt(number, 10, 64)
+}
+
+// Convert String to Uint64
+func toUint(number string) (uint64, error) {
+	return strconv.ParseUint(number, 10, 64)
+}
+
+// Convert String to float64
+func toFloat(number string) (float64, error) {
+	return strconv.ParseFloat(number, 64)
+}
+
+func (c *Context) initWriter() {
+	if c.Req.Method == "HEAD" {
+		c.pri.reswrite = ioutil.Discard
+		c.Res.Header().Set("Connection", "close")
+		return
+	}
+	c.pri.reswrite = c.Res.rw
+	c.Res.Header().Set("Content-Encoding", "plain")
+
+}
+
+func (c *Context) initTrueHost() {
+	switch {
+	case c.Req.Header.Get("Host") != "":
+		c.Req.Host = c.Req.Header.Get("Host")
+	case c.Req.Header.Get("X-Forwarded-Host") != "":
+		c.Req.Host = c.Req.Header.Get("X-Forwarded-Host")
+	case c.Req.Header.Get("X-Forwarded-Server") != "":
+		c.Req.Host = c.Req.Header.Get("X-Forwarded-Server")
+	}
+	c.Req.URL.Host = c.Req.Host
+}
+
+func (c *Context) initTrueRemoteAddr() {
+	address := ""
+
+	switch {
+	case c.Req.Header.Get("X-Real-Ip") != "":
+		address = c.Req.Header.Get("X-Real-Ip")
+	case c.Req.Header.Get("X-Forwarded-For") != "":
+		address = c.Req.Header.Get("X-Forwarded-For")
+	}
+
+	if address != "" {
+		c.Req.RemoteAddr = net.JoinHostPort(strings.Trim(address, "[]"), "1234")
+	}
+}
+
+func (c *Context) initTruePath() {
+	switch {
+	case c.Req.Header.Get("X-Original-Url") != "":
+		// For compatibility with IIS
+		urls := strings.Split(c.Req.Header.Get("X-Original-Url"), "?")
+		c.Req.URL.Path = urls[0]
+		c.pri.path = c.Req.URL.Path
+
+		if len(urls) < 2 {
+			return
+		}
+
+		c.Req.URL.RawQuery = strings.Join(urls[1:], "?")
+	}
+}
+
+func (c *Context) initMethodOverride() {
+	if !c.App.MethodOverride || c.Req.Method != "POST" {
+		return
+	}
+
+	method := c.Req.Header.Get("X-HTTP-Method-Override")
+	if method == "" {
+		switch ct := c.Req.Header.Get("Content-Type"); {
+		case strings.HasPrefix(ct, "application/x-www-form-urlencoded"), strings.HasPrefix(ct, "multipart/form-data"):
+			c.Req.ParseMultipartForm(c.App.FormMemoryLimit)
+			method = c.Req.PostFormValue("_method")
+		}
+	}
+
+	switch method = strings.ToUpper(strings.TrimSpace(method)); method {
+	case "PUT", "PATCH", "DELETE":
+		c.pri.method = c.Req.Method
+		c.Req.Method = method
+	}
+}
+
+// Get Original Request Method, before being overridden by App.MethodOverride
+func (c *Context) OriginalMethod() string {
+	if c.pri.method != "" {
+		return c.pri.method
+	}
+	return c.Req.Method
+}
+
+// Get Remote Address (IP Address) without port number!
+func (c *Context) RemoteAddr() string {
+	ip, _, _ := net.SplitHostPort(c.Req.RemoteAddr)
+	return ip
+}
+
+type AtomicString struct {
+	sync.Mutex
+	s string
+}
+
+func NewAtomicString(s string) *AtomicString {
+	return &AtomicString{s: s}
+}
+
+func (str *AtomicString) String() string {
+	str.Lock()
+	defer str.Unlock()
+	return str.s
+}
+
+func (str *AtomicString) Set(s string) {
+	str.Lock()
+	defer str.Unlock()
+	str.s = s
+}
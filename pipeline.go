@@ -0,0 +1,235 @@
+package core
+
+import (
+	"bytes"
+	"compress/gzip"
+	"hash"
+	"io"
+	"net/http"
+	"runtime/debug"
+)
+
+// Pipeline Stage, wraps w and returns the writer of the stage.
+// Close must flush the stage (e.g. gzip footer) without closing w.
+type PipeStage func(c *Context, w io.Writer) (io.WriteCloser, error)
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+type funcWriteCloser struct {
+	io.Writer
+	close func() error
+}
+
+func (f funcWriteCloser) Close() error {
+	return f.close()
+}
+
+// Named chain of writer stages in front of Res, registered in c.Pub.Writers.
+// Written data goes through the stages in order they were added.
+// Stages are created on first write and closed in order at request end,
+// or when a buffering middleware (AutoETag, ResponseCache) gets the output.
+type Pipeline struct {
+	c       *Context
+	name    string
+	stages  []PipeStage
+	writers []io.WriteCloser
+	err     error
+	built   bool
+	closed  bool
+}
+
+// Create Pipeline and register it as Writer, e.g. Pipeline("gzip", PipeGzip(gzip.BestSpeed)).
+// Json, Xml and MethodHtml5 output go through the Writer named "gzip".
+func (io_ IO) Pipeline(name string, stages ...PipeStage) *Pipeline {
+	if _, ok := io_.c.Pub.Writers[name]; ok {
+		panic(ErrorStr("Writer '" + name + "' already exists"))
+	}
+	p := &Pipeline{c: io_.c, name: name, stages: stages}
+	io_.c.Pub.Writers[name] = p
+	io_.c.pri.pipelines = append(io_.c.pri.pipelines, p)
+	return p
+}
+
+// Shortcut of Pipeline("gzip", PipeGzip(gzip.DefaultCompression))
+func (io_ IO) Gzip() *Pipeline {
+	return io_.Pipeline("gzip", PipeGzip(gzip.DefaultCompression))
+}
+
+// Add Stage, must be called before the first write.
+func (p *Pipeline) Add(stage PipeStage) *Pipeline {
+	if p.built {
+		panic(ErrorStr("Pipeline '" + p.name + "' is already written to"))
+	}
+	p.stages = append(p.stages, stage)
+	return p
+}
+
+func (p *Pipeline) build() error {
+	p.built = true
+	var w io.Writer = p.c.Res
+	writers := make([]io.WriteCloser, len(p.stages))
+	for i := len(p.stages) - 1; i >= 0; i-- {
+		wc, err := p.stages[i](p.c, w)
+		if err != nil {
+			return err
+		}
+		writers[i] = wc
+		w = wc
+	}
+	p.writers = writers
+	return nil
+}
+
+// Implement io.Writer
+func (p *Pipeline) Write(data []byte) (int, error) {
+	if p.err != nil {
+		return 0, p.err
+	}
+	if p.closed {
+		return 0, ErrorStr("Pipeline '" + p.name + "' is closed")
+	}
+	if !p.built {
+		// Detect before the stages transform it
+		if p.c.Res.Header().Get("Content-Type") == "" {
+			p.c.Res.Header().Set("Content-Type", http.DetectContentType(data))
+		}
+		if p.err = p.build(); p.err != nil {
+			return 0, p.err
+		}
+	}
+	if len(p.writers) == 0 {
+		n, err := p.c.Res.Write(data)
+		p.err = err
+		return n, err
+	}
+	n, err := p.writers[0].Write(data)
+	p.err = err
+	return n, err
+}
+
+// Close stages in order, returns first error of Write or Close. Called at request end.
+func (p *Pipeline) Close() error {
+	if p.closed {
+		return p.err
+	}
+	p.closed = true
+	for _, w := range p.writers {
+		if err := w.Close(); err != nil && p.err == nil {
+			p.err = err
+		}
+	}
+	return p.err
+}
+
+// Implement http.Flusher, flushes stages in order (e.g. gzip) and than Res.
+func (p *Pipeline) Flush() {
+	if p.err != nil || p.closed {
+		return
+	}
+	for _, w := range p.writers {
+		if fl, ok := w.(interface{ Flush() error }); ok {
+			if p.err = fl.Flush(); p.err != nil {
+				return
+			}
+		}
+	}
+	p.c.Res.Flush()
+}
+
+// First error of Write or Close
+func (p *Pipeline) Err() error {
+	return p.err
+}
+
+// Close pipelines before the output check, errors go to HandleError if nothing was sent,
+// otherwise to PanicHandler. Closed pipelines are removed from c.Pub.Writers, later output goes to Res.
+func (c *Context) closePipelines() {
+	for _, p := range c.pri.pipelines {
+		if c.Pub.Writers[p.name] == p {
+			delete(c.Pub.Writers, p.name)
+		}
+		err := p.Close()
+		if err == nil {
+			continue
+		}
+		if !c.Terminated() {
+			c.HandleError(err)
+			continue
+		}
+		c.App.panicHandler().Panic(c, err, debug.Stack())
+	}
+	c.pri.pipelines = nil
+}
+
+// Gzip Stage, passes through if client does not accept gzip.
+func PipeGzip(level int) PipeStage {
+	return func(c *Context, w io.Writer) (io.WriteCloser, error) {
+		header := c.Res.Header()
+		header.Add("Vary", "Accept-Encoding")
+		if !acceptsEncoding(c.Req, "gzip") {
+			return nopWriteCloser{w}, nil
+		}
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		return gzip.NewWriterLevel(w, level)
+	}
+}
+
+// Tee Stage, copies data to w (e.g. cache buffer) as it passes.
+func PipeTee(tee io.Writer) PipeStage {
+	return func(c *Context, w io.Writer) (io.WriteCloser, error) {
+		return nopWriteCloser{io.MultiWriter(w, tee)}, nil
+	}
+}
+
+// Hash Stage, done is called with the sum of passed data on Close.
+func PipeHash(h hash.Hash, done func(sum []byte)) PipeStage {
+	return func(c *Context, w io.Writer) (io.WriteCloser, error) {
+		return funcWriteCloser{io.MultiWriter(w, h), func() error {
+			done(h.Sum(nil))
+			return nil
+		}}, nil
+	}
+}
+
+// Encryption Stage, see Crypto.AesOfbWriter
+func PipeEncrypt(blockKey []byte) PipeStage {
+	return func(c *Context, w io.Writer) (io.WriteCloser, error) {
+		ew, err := c.Crypto().AesOfbWriter(w, blockKey)
+		if err != nil {
+			return nil, err
+		}
+		return nopWriteCloser{ew}, nil
+	}
+}
+
+// Transform Stage, buffers the whole output and writes fn(output) on Close.
+func PipeTransform(fn func([]byte) ([]byte, error)) PipeStage {
+	return func(c *Context, w io.Writer) (io.WriteCloser, error) {
+		buf := &bytes.Buffer{}
+		return funcWriteCloser{buf, func() error {
+			out, err := fn(buf.Bytes())
+			if err != nil {
+				return err
+			}
+			_, err = w.Write(out)
+			return err
+		}}, nil
+	}
+}
+
+// HTML Minify Stage, see HtmlMinifier. Passes through in Debug.
+func PipeMinifyHtml() PipeStage {
+	return func(c *Context, w io.Writer) (io.WriteCloser, error) {
+		if c.App.Debug {
+			return nopWriteCloser{w}, nil
+		}
+		return NewHtmlMinifier(w), nil
+	}
+}
@@ -0,0 +1,197 @@
+package core
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha1"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPipeline(t *testing.T) {
+	App := NewApp()
+
+	var sum []byte
+	tee := &bytes.Buffer{}
+
+	App.DefaultRouter = NewDirRouter().Register("json", RouteHandlerFunc(func(c *Context) {
+		c.IO().Gzip().Add(PipeTee(tee))
+		c.Json().Send(map[string]string{"hello": "world"})
+	})).Register("upper", RouteHandlerFunc(func(c *Context) {
+		c.IO().Pipeline("upper", PipeTransform(func(b []byte) ([]byte, error) {
+			return bytes.ToUpper(b), nil
+		}), PipeHash(sha1.New(), func(b []byte) {
+			sum = b
+		}))
+		if c.IO().TryPushStr("upper", "hello ") != nil {
+			t.Fail()
+		}
+		c.IO().PushStr("upper", "world")
+		if c.IO().TryPushStr("missing", "x") == nil {
+			t.Fail()
+		}
+	})).Register("fail", RouteHandlerFunc(func(c *Context) {
+		c.IO().Pipeline("fail", PipeTransform(func(b []byte) ([]byte, error) {
+			return nil, ErrConflict("transform failed")
+		}))
+		c.IO().PushStr("fail", "data")
+	})).Register("pull", RouteHandlerFunc(func(c *Context) {
+		c.Pub.Readers["body"] = strings.NewReader(strings.Repeat("x", 3000))
+		b, err := c.IO().TryPull("body")
+		if err != nil || len(b) != 3000 {
+			t.Fail()
+		}
+		if _, err := c.IO().TryPullStr("missing"); err == nil || c.IO().PullStr("missing") != "" {
+			t.Fail()
+		}
+		c.Fmt().Print("ok")
+	}))
+
+	serve := func(path, encoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Accept-Encoding", encoding)
+		res := httptest.NewRecorder()
+		App.ServeHTTP(res, req)
+		return res
+	}
+
+	res := serve("/json", "gzip")
+	gz, err := gzip.NewReader(res.Body)
+	if err != nil || res.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadAll(gz); strings.TrimSpace(string(b)) != `{"hello":"world"}` || !strings.Contains(tee.String(), "hello") {
+		t.Fail()
+	}
+
+	if res = serve("/json", ""); strings.TrimSpace(res.Body.String()) != `{"hello":"world"}` || res.Header().Get("Content-Encoding") == "gzip" {
+		t.Fail()
+	}
+
+	if res = serve("/upper", ""); res.Body.String() != "HELLO WORLD" || len(sum) != sha1.Size {
+		t.Fail()
+	}
+	if s := sha1.Sum([]byte("HELLO WORLD")); !bytes.Equal(sum, s[:]) {
+		t.Fail()
+	}
+
+	if res = serve("/fail", ""); res.Code != http.StatusConflict {
+		t.Fail()
+	}
+
+	if res = serve("/pull", ""); res.Body.String() != "ok" {
+		t.Fail()
+	}
+}
+
+func TestPipelineBuffered(t *testing.T) {
+	App := NewApp()
+
+	rc := NewResponseCache(NewLRUStore(1024), time.Minute)
+
+	upper := RouteHandlerFunc(func(c *Context) {
+		c.IO().Pipeline("upper", PipeTransform(func(b []byte) ([]byte, error) {
+			return bytes.ToUpper(b), nil
+		}))
+		c.IO().PushStr("upper", "hello")
+	})
+
+	App.DefaultRouter = NewDirRouter().
+		Register("etag", WithMiddleware(upper, AutoETag)).
+		Register("cached", WithMiddleware(upper, rc.Middleware))
+
+	serve := func(path string) *httptest.ResponseRecorder {
+		res := httptest.NewRecorder()
+		App.ServeHTTP(res, httptest.NewRequest("GET", path, nil))
+		return res
+	}
+
+	// Stages are closed before the buffering middleware finishes
+	if res := serve("/etag"); res.Body.String() != "HELLO" || res.Header().Get("ETag") != WeakETag([]byte("HELLO")) {
+		t.Errorf("%q %q", res.Body.String(), res.Header().Get("ETag"))
+	}
+
+	serve("/cached")
+	if res := serve("/cached"); res.Body.String() != "HELLO" || res.Header().Get("X-Cache") != "HIT" {
+		t.Errorf("%q", res.Body.String())
+	}
+}
+
+func TestPipelineFlush(t *testing.T) {
+	App := NewApp()
+
+	res := httptest.NewRecorder()
+
+	App.DefaultRouter = NewDirRouter().Register("stream", RouteHandlerFunc(func(c *Context) {
+		p := c.IO().Gzip()
+		p.Write([]byte("hello"))
+		p.Flush()
+
+		// Readable by the client before the request ends
+		gz, err := gzip.NewReader(bytes.NewReader(res.Body.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b := make([]byte, 5)
+		if _, err := io.ReadFull(gz, b); err != nil || string(b) != "hello" || !res.Flushed {
+			t.Fail()
+		}
+	}))
+
+	req := httptest.NewRequest("GET", "/stream", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	App.ServeHTTP(res, req)
+}
+
+func TestPipelineOuterMiddleware(t *testing.T) {
+	App := NewApp()
+
+	App.Use(func(c *Context, next func()) {
+		c.IO().Gzip()
+		next()
+		if !c.Terminated() {
+			c.Error(404, nil)
+		}
+	})
+
+	empty := RouteHandlerFunc(func(c *Context) {
+		// Write nothing
+	})
+
+	App.DefaultRouter = NewDirRouter().RootDir(empty).Register("etag", WithMiddleware(empty, AutoETag))
+
+	serve := func(path, encoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("Accept", "application/json")
+		req.Header.Set("Accept-Encoding", encoding)
+		res := httptest.NewRecorder()
+		App.ServeHTTP(res, req)
+		return res
+	}
+
+	// Error written after next still goes through the open Pipeline
+	res := serve("/", "")
+	if res.Code != 404 || !strings.Contains(res.Body.String(), "404") {
+		t.Errorf("%d %q", res.Code, res.Body.String())
+	}
+
+	res = serve("/", "gzip")
+	gz, err := gzip.NewReader(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := ioutil.ReadAll(gz); res.Code != 404 || !strings.Contains(string(b), "404") {
+		t.Errorf("%d %q", res.Code, b)
+	}
+
+	// Closed by AutoETag, later output goes to Res
+	res = serve("/etag", "gzip")
+	if res.Code != 404 || !strings.Contains(res.Body.String(), "404") {
+		t.Errorf("%d %q", res.Code, res.Body.String())
+	}
+}
@@ -0,0 +1,328 @@
+package core
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+type ResourceInterface interface {
+	View(*Context)
+	Prepare() error
+	Index() error
+	Create() error
+	Show() error
+	Update() error
+	Destroy() error
+	New() error
+	Edit() error
+	Finish()
+	getType() reflect.Type
+	setType(reflect.Type)
+
+	asn_Core_0004() // Assert Serial Number
+}
+
+/*
+A CRUD Controller, actions are mapped by ResourceRoute.
+
+	GET    /posts          Index
+	POST   /posts          Create
+	GET    /posts/new      New
+	GET    /posts/1        Show
+	PUT    /posts/1        Update (PATCH as well)
+	DELETE /posts/1        Destroy
+	GET    /posts/1/edit   Edit
+
+Id is stored in c.Pub.Group as "Id", actions not implemented return ErrMethodNotAllowed.
+*/
+type Resource struct {
+	C  *Context `json:"-" xml:"-"`
+	_t reflect.Type
+	_s sync.RWMutex
+}
+
+func (re *Resource) View(c *Context) {
+	re.C = c
+}
+
+func (re *Resource) Prepare() error {
+	return nil
+}
+
+func (re *Resource) Index() error {
+	return ErrMethodNotAllowed
+}
+
+func (re *Resource) Create() error {
+	return ErrMethodNotAllowed
+}
+
+func (re *Resource) Show() error {
+	return ErrMethodNotAllowed
+}
+
+func (re *Resource) Update() error {
+	return ErrMethodNotAllowed
+}
+
+func (re *Resource) Destroy() error {
+	return ErrMethodNotAllowed
+}
+
+func (re *Resource) New() error {
+	return ErrMethodNotAllowed
+}
+
+func (re *Resource) Edit() error {
+	return ErrMethodNotAllowed
+}
+
+func (re *Resource) Finish() {
+	// Do nothing
+}
+
+func (re *Resource) getType() reflect.Type {
+	re._s.RLock()
+	defer re._s.RUnlock()
+	return re._t
+}
+
+func (re *Resource) setType(t reflect.Type) {
+	re._s.Lock()
+	defer re._s.Unlock()
+	re._t = t
+}
+
+// Assert Serial Number
+func (re *Resource) asn_Core_0004() {
+	// Do nothing
+}
+
+func execResourceInterface(c *Context, re ResourceInterface, action string) {
+	t := re.getType()
+	if t == nil {
+		t = reflect.Indirect(reflect.ValueOf(re)).Type()
+		re.setType(t)
+	}
+
+	vc := reflect.New(t)
+
+	in := []reflect.Value{reflect.ValueOf(c)}
+	vc.MethodByName("View").Call(in)
+
+	c.Auto().PopulateStructFieldsValue(vc, "C")
+
+	if c.Terminated() {
+		return
+	}
+
+	c.App.services.inject(c, vc)
+
+	if c.Terminated() {
+		return
+	}
+
+	in = []reflect.Value{}
+
+	call := func(name string) bool {
+		out := vc.MethodByName(name).Call(in)
+		if err, _ := out[0].Interface().(error); err != nil {
+			c.HandleError(err)
+			return false
+		}
+		return !c.Terminated()
+	}
+
+	if !call("Prepare") {
+		return
+	}
+
+	call(action)
+
+	vc.MethodByName("Finish").Call(in)
+}
+
+// Routes of a Resource, implement RouteHandler
+type ResourceRoute struct {
+	sync.RWMutex
+	name       string
+	param      string
+	controller ResourceInterface
+	children   map[string]*ResourceRoute
+}
+
+// Construct New Resource Route, name is the collection path segment, e.g. "posts"
+func NewResourceRoute(name string, controller ResourceInterface) *ResourceRoute {
+	rr := &ResourceRoute{
+		name:       name,
+		param:      resourceParam(name),
+		controller: controller,
+		children:   map[string]*ResourceRoute{},
+	}
+	rr.controller.setType(reflect.Indirect(reflect.ValueOf(controller)).Type())
+	return rr
+}
+
+// "posts" to "PostId", "categories" to "CategoryId"
+func resourceParam(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies"):
+		name = name[:len(name)-3] + "y"
+	case strings.HasSuffix(name, "s"):
+		name = name[:len(name)-1]
+	}
+	return strings.Title(name) + "Id"
+}
+
+// Set Group name of Id for nested resources, default is derived from name, e.g. "posts" is "PostId"
+func (rr *ResourceRoute) Param(param string) *ResourceRoute {
+	rr.Lock()
+	defer rr.Unlock()
+	rr.param = param
+	return rr
+}
+
+// Nest Resource under member, e.g. /posts/1/comments, returns the nested Resource Route.
+func (rr *ResourceRoute) Nest(name string, controller ResourceInterface) *ResourceRoute {
+	child := NewResourceRoute(name, controller)
+	rr.Lock()
+	defer rr.Unlock()
+	rr.children[name] = child
+	return child
+}
+
+/*
+Register URL names to URL Reverse, prefix is the path the resource is mounted under.
+
+	posts.index   /posts
+	posts.new     /posts/new
+	posts.show    /posts/%v
+	posts.edit    /posts/%v/edit
+
+Nested resources are included, e.g. posts.comments.show /posts/%v/comments/%v
+*/
+func (rr *ResourceRoute) Reverse(u *URLReverse, prefix string) *ResourceRoute {
+	rr.reverse(u, "", prefix)
+	return rr
+}
+
+func (rr *ResourceRoute) reverse(u *URLReverse, name, prefix string) {
+	rr.RLock()
+	defer rr.RUnlock()
+
+	name += rr.name
+	prefix += "/" + rr.name
+
+	u.RegisterMap(URLReverseMap{
+		name + ".index": prefix,
+		name + ".new":   prefix + "/new",
+		name + ".show":  prefix + "/%v",
+		name + ".edit":  prefix + "/%v/edit",
+	})
+
+	for _, child := range rr.children {
+		child.reverse(u, name+".", prefix+"/%v")
+	}
+}
+
+// Implement RouteHandler
+func (rr *ResourceRoute) View(c *Context) {
+	path := strings.Trim(c.pri.path, "/")
+	segments := []string{}
+	if path != "" {
+		segments = strings.Split(path, "/")
+	}
+	rr.dispatch(c, segments)
+}
+
+func (rr *ResourceRoute) dispatch(c *Context, segments []string) {
+	action, allow := "", ""
+	method := c.Req.Method
+
+	member := func(route string) {
+		c.Pub.Group.Set("Id", segments[0])
+		c.pri.route += route
+	}
+
+	switch {
+	case len(segments) == 0:
+		allow = "GET, HEAD, POST"
+		switch method {
+		case "GET", "HEAD":
+			action = "Index"
+		case "POST":
+			action = "Create"
+		}
+	case segments[0] == "":
+		c.Error404()
+		return
+	case len(segments) == 1 && segments[0] == "new":
+		c.pri.route += "/new"
+		allow = "GET, HEAD"
+		if method == "GET" || method == "HEAD" {
+			action = "New"
+		}
+	case len(segments) == 1:
+		member("/{Id}")
+		allow = "GET, HEAD, PUT, PATCH, DELETE"
+		switch method {
+		case "GET", "HEAD":
+			action = "Show"
+		case "PUT", "PATCH":
+			action = "Update"
+		case "DELETE":
+			action = "Destroy"
+		}
+	case len(segments) == 2 && segments[1] == "edit":
+		member("/{Id}/edit")
+		allow = "GET, HEAD"
+		if method == "GET" || method == "HEAD" {
+			action = "Edit"
+		}
+	default:
+		rr.RLock()
+		child := rr.children[segments[1]]
+		param := rr.param
+		rr.RUnlock()
+
+		if child == nil {
+			c.Error404()
+			return
+		}
+
+		// Parent id is only the param, "Id" belongs to the child member
+		delete(c.Pub.Group, "Id")
+		c.Pub.Group.Set(param, segments[0])
+		c.pri.route += "/{" + param + "}/" + child.name
+		child.dispatch(c, segments[2:])
+		return
+	}
+
+	c.pri.curpath += c.pri.path
+	c.pri.path = ""
+
+	c.Res.Header().Set("Allow", allow)
+
+	if action == "" {
+		c.Error405()
+		return
+	}
+
+	execResourceInterface(c, rr.controller, action)
+}
+
+// Register Resource under "/name", returns Resource Route for nesting.
+func (ro *Router) Resource(name string, controller ResourceInterface) *ResourceRoute {
+	rr := NewResourceRoute(name, controller)
+	ro.Register(`^/`+regexp.QuoteMeta(name)+`(?:/|$)`, rr)
+	return rr
+}
+
+// Register Resource to Directory, returns Resource Route for nesting.
+func (dir *DirRouter) Resource(name string, controller ResourceInterface) *ResourceRoute {
+	rr := NewResourceRoute(name, controller)
+	dir.Register(name, NoDirLock{rr})
+	return rr
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type resourcePost struct {
+	Resource
+	Id string
+}
+
+func (re *resourcePost) Index() error {
+	re.C.Fmt().Print("index")
+	return nil
+}
+
+func (re *resourcePost) Show() error {
+	if re.Id == "404" {
+		return ErrNotFound("")
+	}
+	re.C.Fmt().Print("show ", re.Id)
+	return nil
+}
+
+func (re *resourcePost) Edit() error {
+	re.C.Fmt().Print("edit ", re.Id)
+	return nil
+}
+
+type resourceComment struct {
+	Resource
+	Id     string
+	PostId string
+}
+
+func (re *resourceComment) Index() error {
+	re.C.Fmt().Print("comments ", re.PostId, " ", re.Id)
+	return nil
+}
+
+func (re *resourceComment) Show() error {
+	re.C.Fmt().Print("comment ", re.PostId, " ", re.Id)
+	return nil
+}
+
+func TestResource(t *testing.T) {
+	App := NewApp()
+
+	router := NewRouter()
+	router.Resource("posts", &resourcePost{}).Nest("comments", &resourceComment{})
+
+	dir := NewDirRouter()
+	posts := dir.Resource("posts", &resourcePost{})
+	posts.Nest("comments", &resourceComment{})
+	posts.Reverse(App.URLRev, "/api")
+
+	App.DefaultRouter = NewDirRouter().Register("api", dir).Asterisk(NoDirLock{RouteReset{router}})
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	do := func(method, path string) (int, string, string) {
+		req, err := http.NewRequest(method, ts.URL+path, nil)
+		Check(err)
+		res, err := http.DefaultClient.Do(req)
+		Check(err)
+		b, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		return res.StatusCode, string(b), res.Header.Get("Allow")
+	}
+
+	for _, prefix := range []string{"", "/api"} {
+		if status, body, _ := do("GET", prefix+"/posts"); status != 200 || body != "index" {
+			t.Fail()
+		}
+
+		if status, body, _ := do("GET", prefix+"/posts/7"); status != 200 || body != "show 7" {
+			t.Fail()
+		}
+
+		if status, body, _ := do("GET", prefix+"/posts/7/edit"); status != 200 || body != "edit 7" {
+			t.Fail()
+		}
+
+		if status, body, _ := do("GET", prefix+"/posts/7/comments/3"); status != 200 || body != "comment 7 3" {
+			t.Fail()
+		}
+
+		if status, body, _ := do("GET", prefix+"/posts/7/comments"); status != 200 || body != "comments 7 " {
+			t.Fail()
+		}
+
+		if status, _, _ := do("GET", prefix+"/posts/404"); status != 404 {
+			t.Fail()
+		}
+
+		if status, _, _ := do("GET", prefix+"/posts/7/other/3"); status != 404 {
+			t.Fail()
+		}
+
+		if status, _, allow := do("POST", prefix+"/posts/7"); status != 405 || allow != "GET, HEAD, PUT, PATCH, DELETE" {
+			t.Fail()
+		}
+
+		if status, _, allow := do("DELETE", prefix+"/posts/7"); status != 405 || allow == "" {
+			t.Fail()
+		}
+	}
+
+	c := &Context{App: App}
+
+	if c.Url().Reverse("posts.comments.show", 7, 3) != "/api/posts/7/comments/3" || c.Url().Reverse("posts.edit", 7) != "/api/posts/7/edit" {
+		t.Fail()
+	}
+}
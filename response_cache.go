@@ -0,0 +1,318 @@
+package core
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Response captured by ResponseCache
+type CachedResponse struct {
+	Status  int
+	Header  http.Header
+	Body    []byte
+	Route   string
+	Created time.Time
+	Expires time.Time
+}
+
+func (cr *CachedResponse) size() int64 {
+	size := int64(len(cr.Body) + len(cr.Route))
+	for name, values := range cr.Header {
+		for _, value := range values {
+			size += int64(len(name) + len(value))
+		}
+	}
+	return size
+}
+
+// Response Cache Store, implementations must be safe for concurrent use.
+type ResponseCacheStore interface {
+	// Get unexpired response
+	Get(key string) (*CachedResponse, bool)
+	Set(key string, res *CachedResponse)
+	Delete(key string)
+	// Delete every response of route, returns number deleted
+	DeleteRoute(route string) int
+}
+
+// In-memory Least Recently Used Store, capped by size in bytes.
+type LRUStore struct {
+	sync.Mutex
+	MaxBytes int64
+	size     int64
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type lruEntry struct {
+	key  string
+	res  *CachedResponse
+	size int64
+}
+
+// Construct New LRU Store
+func NewLRUStore(maxBytes int64) *LRUStore {
+	return &LRUStore{MaxBytes: maxBytes, ll: list.New(), items: map[string]*list.Element{}}
+}
+
+func (s *LRUStore) Get(key string) (*CachedResponse, bool) {
+	s.Lock()
+	defer s.Unlock()
+	el := s.items[key]
+	if el == nil {
+		return nil, false
+	}
+	entry := el.Value.(*lruEntry)
+	if time.Now().After(entry.res.Expires) {
+		s.remove(el)
+		return nil, false
+	}
+	s.ll.MoveToFront(el)
+	return entry.res, true
+}
+
+func (s *LRUStore) Set(key string, res *CachedResponse) {
+	s.Lock()
+	defer s.Unlock()
+
+	if el := s.items[key]; el != nil {
+		s.remove(el)
+	}
+
+	entry := &lruEntry{key, res, res.size()}
+	if entry.size > s.MaxBytes {
+		return
+	}
+
+	s.items[key] = s.ll.PushFront(entry)
+	s.size += entry.size
+
+	for s.size > s.MaxBytes {
+		s.remove(s.ll.Back())
+	}
+}
+
+func (s *LRUStore) remove(el *list.Element) {
+	entry := el.Value.(*lruEntry)
+	s.ll.Remove(el)
+	delete(s.items, entry.key)
+	s.size -= entry.size
+}
+
+func (s *LRUStore) Delete(key string) {
+	s.Lock()
+	defer s.Unlock()
+	if el := s.items[key]; el != nil {
+		s.remove(el)
+	}
+}
+
+func (s *LRUStore) DeleteRoute(route string) int {
+	s.Lock()
+	defer s.Unlock()
+	count := 0
+	for _, el := range s.items {
+		if el.Value.(*lruEntry).res.Route == route {
+			s.remove(el)
+			count++
+		}
+	}
+	return count
+}
+
+// Number of stored responses and their size in bytes
+func (s *LRUStore) Len() (int, int64) {
+	s.Lock()
+	defer s.Unlock()
+	return s.ll.Len(), s.size
+}
+
+// Set route name of the response for ResponseCache.PurgeRoute, default is c.RoutePattern()
+func (ca Cache) Route(name string) Cache {
+	ca.c.pri.cacheRoute = name
+	return ca
+}
+
+type responseCacheCall struct {
+	done chan struct{}
+	res  *CachedResponse
+}
+
+// Server-side Response Cache for GET requests, HEAD is answered from the GET response. Use as MiddlewareFunc (rc.Middleware).
+// Only 200 responses without Set-Cookie, private or no-store are stored,
+// and only if every header named by their Vary is in rc.Vary.
+// Requests carrying Authorization or the session cookie bypass the cache.
+type ResponseCache struct {
+	Store ResponseCacheStore
+	TTL   time.Duration
+	// Request headers included in the key
+	Vary []string
+	// Also cache requests carrying Authorization or the session cookie, only if the response does not depend on them.
+	CacheAuthorized bool
+
+	mu     sync.Mutex
+	flight map[string]*responseCacheCall
+}
+
+// Construct New Response Cache
+func NewResponseCache(store ResponseCacheStore, ttl time.Duration, vary ...string) *ResponseCache {
+	return &ResponseCache{Store: store, TTL: ttl, Vary: vary, flight: map[string]*responseCacheCall{}}
+}
+
+// Cache key, host, path, sorted query and Vary headers.
+func (rc *ResponseCache) Key(c *Context) string {
+	buf := &bytes.Buffer{}
+	buf.WriteString(strings.ToLower(c.Req.Host))
+	buf.WriteString(c.Req.URL.Path)
+	if query := c.Req.URL.Query(); len(query) > 0 {
+		buf.WriteString("?" + query.Encode())
+	}
+	for _, name := range rc.Vary {
+		fmt.Fprintf(buf, "\n%s:%s", http.CanonicalHeaderKey(name), strings.Join(c.Req.Header[http.CanonicalHeaderKey(name)], ","))
+	}
+	return buf.String()
+}
+
+// Purge response by key
+func (rc *ResponseCache) Purge(key string) {
+	rc.Store.Delete(key)
+}
+
+// Purge every response of route, see Cache.Route
+func (rc *ResponseCache) PurgeRoute(route string) int {
+	return rc.Store.DeleteRoute(route)
+}
+
+func (rc *ResponseCache) serve(c *Context, res *CachedResponse, state string) {
+	header := c.Res.Header()
+	for name, values := range res.Header {
+		header[name] = append([]string{}, values...)
+	}
+	header.Set("X-Cache", state)
+	if state == "HIT" {
+		header.Set("Age", fmt.Sprint(int64(time.Since(res.Created)/time.Second)))
+	}
+	c.Res.WriteHeader(res.Status)
+	c.Res.Write(res.Body)
+}
+
+func (rc *ResponseCache) cacheable(rb *responseBuffer) bool {
+	if rb == nil || rb.status != http.StatusOK {
+		return false
+	}
+	header := rb.Header()
+	if header.Get("Set-Cookie") != "" {
+		return false
+	}
+	// Response depends on request headers missing from the key (e.g. Accept-Encoding from PipeGzip)
+	for _, value := range header["Vary"] {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" && !rc.varies(name) {
+				return false
+			}
+		}
+	}
+	return !headerHasToken(header, "Cache-Control", "no-store") && !headerHasToken(header, "Cache-Control", "private")
+}
+
+func (rc *ResponseCache) varies(name string) bool {
+	for _, vary := range rc.Vary {
+		if strings.EqualFold(vary, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rc *ResponseCache) authorized(c *Context) bool {
+	if c.Req.Header.Get("Authorization") != "" {
+		return true
+	}
+	_, err := c.Req.Cookie(c.App.SessionCookieName.String())
+	return err == nil
+}
+
+// Implement MiddlewareFunc
+func (rc *ResponseCache) Middleware(c *Context, next func()) {
+	if c.Req.Method != "GET" && c.Req.Method != "HEAD" {
+		next()
+		return
+	}
+
+	if !rc.CacheAuthorized && rc.authorized(c) {
+		next()
+		return
+	}
+
+	key := rc.Key(c)
+
+	if res, ok := rc.Store.Get(key); ok {
+		rc.serve(c, res, "HIT")
+		return
+	}
+
+	// Only GET is stored, HEAD is answered from it since handlers may skip the body.
+	if c.Req.Method == "HEAD" {
+		next()
+		return
+	}
+
+	// Single-flight, concurrent misses of the same key wait for the first one.
+	rc.mu.Lock()
+	if call := rc.flight[key]; call != nil {
+		rc.mu.Unlock()
+		<-call.done
+		if call.res != nil {
+			rc.serve(c, call.res, "HIT")
+			return
+		}
+		next()
+		return
+	}
+	call := &responseCacheCall{done: make(chan struct{})}
+	rc.flight[key] = call
+	rc.mu.Unlock()
+
+	defer func() {
+		rc.mu.Lock()
+		delete(rc.flight, key)
+		rc.mu.Unlock()
+		close(call.done)
+	}()
+
+	rb := bufferResponse(c, next)
+	if !rc.cacheable(rb) {
+		if rb != nil {
+			rb.flushTo(c)
+		}
+		return
+	}
+
+	route := c.pri.cacheRoute
+	if route == "" {
+		route = c.RoutePattern()
+	}
+
+	header := http.Header{}
+	for name, values := range rb.Header() {
+		header[name] = append([]string{}, values...)
+	}
+
+	now := time.Now()
+	call.res = &CachedResponse{
+		Status:  rb.status,
+		Header:  header,
+		Body:    append([]byte{}, rb.buf.Bytes()...),
+		Route:   route,
+		Created: now,
+		Expires: now.Add(rc.TTL),
+	}
+	rc.Store.Set(key, call.res)
+
+	rc.serve(c, call.res, "MISS")
+}
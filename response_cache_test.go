@@ -0,0 +1,191 @@
+package core
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestResponseCache(t *testing.T) {
+	App := NewApp()
+
+	store := NewLRUStore(1024)
+	rc := NewResponseCache(store, time.Minute, "Accept-Language")
+
+	var mu sync.Mutex
+	count := 0
+
+	App.DefaultRouter = NewDirRouter().Use(rc.Middleware).Register("news", RouteHandlerFunc(func(c *Context) {
+		mu.Lock()
+		count++
+		n := count
+		mu.Unlock()
+		time.Sleep(20 * time.Millisecond)
+		c.Cache().Route("news")
+		c.Fmt().Print("news ", n)
+	})).Register("private", RouteHandlerFunc(func(c *Context) {
+		c.Cache().Private(time.Minute)
+		c.Fmt().Print("private")
+	}))
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	get := func(path, lang string) (*http.Response, string) {
+		req, _ := http.NewRequest("GET", ts.URL+path, nil)
+		req.Header.Set("Accept-Language", lang)
+		res, err := http.DefaultClient.Do(req)
+		Check(err)
+		b, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		return res, string(b)
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, body := get("/news", "en"); body != "news 1" {
+				t.Fail()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if res, body := get("/news", "en"); body != "news 1" || res.Header.Get("X-Cache") != "HIT" || count != 1 {
+		t.Fail()
+	}
+
+	if _, body := get("/news", "fr"); body != "news 2" {
+		t.Fail()
+	}
+
+	if rc.PurgeRoute("news") != 2 {
+		t.Fail()
+	}
+
+	if res, body := get("/news", "en"); body != "news 3" || res.Header.Get("X-Cache") != "MISS" {
+		t.Fail()
+	}
+
+	get("/private", "en")
+
+	if n, _ := store.Len(); n != 1 {
+		t.Fail()
+	}
+
+	store.MaxBytes = 10
+	store.Set("big", &CachedResponse{Body: make([]byte, 100), Expires: time.Now().Add(time.Minute)})
+
+	if _, ok := store.Get("big"); ok {
+		t.Fail()
+	}
+}
+
+func TestResponseCacheHead(t *testing.T) {
+	App := NewApp()
+
+	rc := NewResponseCache(NewLRUStore(1024), time.Minute)
+
+	App.DefaultRouter = NewDirRouter().Use(rc.Middleware).Register("page", RouteHandlerFunc(func(c *Context) {
+		c.Res.Header().Set("Content-Type", "text/plain")
+		if c.Req.Method == "HEAD" {
+			c.Res.WriteHeader(200)
+			return
+		}
+		c.Fmt().Print("page")
+	}))
+
+	serve := func(method string) *httptest.ResponseRecorder {
+		res := httptest.NewRecorder()
+		App.ServeHTTP(res, httptest.NewRequest(method, "/page", nil))
+		return res
+	}
+
+	if res := serve("HEAD"); res.Code != 200 || res.Header().Get("X-Cache") != "" {
+		t.Fail()
+	}
+
+	if res := serve("GET"); res.Body.String() != "page" || res.Header().Get("X-Cache") != "MISS" {
+		t.Errorf("%q", res.Body.String())
+	}
+
+	if res := serve("HEAD"); res.Header().Get("X-Cache") != "HIT" || res.Body.Len() != 0 {
+		t.Fail()
+	}
+
+	if res := serve("GET"); res.Body.String() != "page" || res.Header().Get("X-Cache") != "HIT" {
+		t.Fail()
+	}
+}
+
+func TestResponseCacheVary(t *testing.T) {
+	App := NewApp()
+
+	store := NewLRUStore(4096)
+	rc := NewResponseCache(store, time.Minute)
+
+	count := 0
+
+	App.DefaultRouter = NewDirRouter().Use(rc.Middleware).Register("gzip", RouteHandlerFunc(func(c *Context) {
+		count++
+		c.IO().Gzip()
+		c.Json().Send("gzip")
+	})).Register("page", RouteHandlerFunc(func(c *Context) {
+		count++
+		c.Fmt().Print("page ", count)
+	}))
+
+	serve := func(path string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		res := httptest.NewRecorder()
+		App.ServeHTTP(res, req)
+		return res
+	}
+
+	// Vary: Accept-Encoding is not in rc.Vary, gzip body must not reach other clients
+	serve("/gzip", http.Header{"Accept-Encoding": {"gzip"}})
+	if res := serve("/gzip", nil); res.Header().Get("Content-Encoding") == "gzip" || res.Body.String() != "\"gzip\"\n" || count != 2 {
+		t.Errorf("%q", res.Body.String())
+	}
+
+	if n, _ := store.Len(); n != 0 {
+		t.Fail()
+	}
+
+	rc.Vary = []string{"accept-encoding"}
+	serve("/gzip", http.Header{"Accept-Encoding": {"gzip"}})
+	if res := serve("/gzip", http.Header{"Accept-Encoding": {"gzip"}}); res.Header().Get("X-Cache") != "HIT" || count != 3 {
+		t.Fail()
+	}
+
+	// Authorization and session cookie bypass the cache
+	count = 0
+	session := App.SessionCookieName.String() + "=abc"
+	for _, header := range []http.Header{{"Authorization": {"Bearer a"}}, {"Cookie": {session}}} {
+		serve("/page", header)
+		if res := serve("/page", header); res.Header().Get("X-Cache") != "" {
+			t.Error(header)
+		}
+	}
+
+	if res := serve("/page", nil); res.Body.String() != "page 5" || res.Header().Get("X-Cache") != "MISS" {
+		t.Error(res.Body.String())
+	}
+
+	if res := serve("/page", http.Header{"Authorization": {"Bearer a"}}); res.Body.String() != "page 6" {
+		t.Error(res.Body.String())
+	}
+
+	rc.CacheAuthorized = true
+	if res := serve("/page", http.Header{"Authorization": {"Bearer a"}}); res.Body.String() != "page 5" || res.Header().Get("X-Cache") != "HIT" {
+		t.Error(res.Body.String())
+	}
+}
@@ -0,0 +1,191 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+type sendOptions struct {
+	disposition string
+	filename    string
+	contentType string
+	modTime     time.Time
+	rate        int64
+}
+
+// Send content with Range support (including multipart byteranges), conditional requests and Content-Disposition.
+type Send struct {
+	c   *Context
+	opt *sendOptions
+}
+
+// Send content, e.g. c.Send().Attachment("report.pdf").Bytes(pdf)
+func (c *Context) Send() Send {
+	return Send{c, &sendOptions{}}
+}
+
+// Download as filename, blank uses the file name of File.
+func (s Send) Attachment(filename string) Send {
+	s.opt.disposition, s.opt.filename = "attachment", filename
+	return s
+}
+
+// Display in browser, filename is used when saved.
+func (s Send) Inline(filename string) Send {
+	s.opt.disposition, s.opt.filename = "inline", filename
+	return s
+}
+
+// Set Content-Type, default detected from file name extension or content.
+func (s Send) ContentType(ctype string) Send {
+	s.opt.contentType = ctype
+	return s
+}
+
+// Set Last-Modified for conditional requests, File defaults to modification time of the file.
+func (s Send) ModTime(modTime time.Time) Send {
+	s.opt.modTime = modTime
+	return s
+}
+
+// Limit transfer rate in bytes per second, zero is unlimited.
+func (s Send) Throttle(bytesPerSecond int64) Send {
+	s.opt.rate = bytesPerSecond
+	return s
+}
+
+func dispositionFallback(r rune) rune {
+	if r >= 0x80 || r < 0x20 || r == 0x7f || r == '"' || r == '\\' {
+		return '_'
+	}
+	return r
+}
+
+// Content-Disposition value, RFC 6266 with ASCII fallback and UTF-8 filename* (RFC 5987).
+func contentDisposition(disposition, filename string) string {
+	if filename == "" {
+		return disposition
+	}
+
+	fallback := strings.Map(dispositionFallback, filename)
+	if fallback == filename {
+		return disposition + `; filename="` + filename + `"`
+	}
+
+	encoded := &bytes.Buffer{}
+	for _, b := range []byte(filename) {
+		if b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9' || strings.IndexByte("!#$&+-.^_`|~", b) >= 0 {
+			encoded.WriteByte(b)
+			continue
+		}
+		fmt.Fprintf(encoded, "%%%02X", b)
+	}
+
+	return disposition + `; filename="` + fallback + `"; filename*=UTF-8''` + encoded.String()
+}
+
+// Write content, name is used for content type detection.
+func (s Send) Reader(name string, content io.ReadSeeker) {
+	header := s.c.Res.Header()
+
+	if s.opt.disposition != "" {
+		header.Set("Content-Disposition", contentDisposition(s.opt.disposition, s.opt.filename))
+	}
+	if s.opt.contentType != "" {
+		header.Set("Content-Type", s.opt.contentType)
+	}
+	if name == "" {
+		name = s.opt.filename
+	}
+	// ServeContent only sets Content-Length without Content-Encoding
+	header.Del("Content-Encoding")
+
+	var w http.ResponseWriter = s.c.Res
+	if s.opt.rate > 0 {
+		w = &throttledWriter{ResponseWriter: s.c.Res, rate: s.opt.rate, start: time.Now(), done: s.c.Req.Context().Done()}
+	}
+
+	http.ServeContent(w, s.c.Req, name, s.opt.modTime, content)
+}
+
+// Write bytes
+func (s Send) Bytes(b []byte) {
+	s.Reader("", bytes.NewReader(b))
+}
+
+// Write file, returns error if the file can not be opened or is a directory.
+func (s Send) File(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		return ErrorStr("Is a directory: " + path)
+	}
+
+	if s.opt.disposition != "" && s.opt.filename == "" {
+		s.opt.filename = filepath.Base(path)
+	}
+	if s.opt.modTime.IsZero() {
+		s.opt.modTime = info.ModTime()
+	}
+
+	s.Reader(filepath.Base(path), file)
+	return nil
+}
+
+// Writes at most rate bytes per second, stops when the client disconnects.
+type throttledWriter struct {
+	http.ResponseWriter
+	rate    int64
+	start   time.Time
+	written int64
+	done    <-chan struct{}
+}
+
+func (tw *throttledWriter) Write(p []byte) (int, error) {
+	chunk := int(tw.rate / 10)
+	if chunk < 1 {
+		chunk = 1
+	}
+
+	total := 0
+	for len(p) > 0 {
+		n := chunk
+		if n > len(p) {
+			n = len(p)
+		}
+		n, err := tw.ResponseWriter.Write(p[:n])
+		total += n
+		tw.written += int64(n)
+		if err != nil {
+			return total, err
+		}
+		p = p[n:]
+
+		wait := time.Duration(tw.written*int64(time.Second)/tw.rate) - time.Since(tw.start)
+		if wait <= 0 {
+			continue
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-tw.done:
+			timer.Stop()
+			return total, ErrorStr("Client disconnected")
+		}
+	}
+	return total, nil
+}
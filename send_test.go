@@ -0,0 +1,90 @@
+package core
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestContentDisposition(t *testing.T) {
+	if contentDisposition("attachment", "report.pdf") != `attachment; filename="report.pdf"` {
+		t.Fail()
+	}
+
+	if contentDisposition("inline", `naïve "x".txt`) != `inline; filename="na_ve _x_.txt"; filename*=UTF-8''na%C3%AFve%20%22x%22.txt` {
+		t.Fail()
+	}
+}
+
+func TestSend(t *testing.T) {
+	dir, err := ioutil.TempDir("", "core-send")
+	Check(err)
+	defer os.RemoveAll(dir)
+	Check(ioutil.WriteFile(filepath.Join(dir, "data.txt"), []byte("0123456789"), 0644))
+
+	App := NewApp()
+
+	App.DefaultRouter = NewDirRouter().Register("bytes", RouteHandlerFunc(func(c *Context) {
+		c.Send().Attachment("data.csv").Bytes([]byte("0123456789"))
+	})).Register("file", RouteHandlerFunc(func(c *Context) {
+		if c.Send().Inline("").Throttle(1000).File(filepath.Join(dir, "data.txt")) != nil {
+			t.Fail()
+		}
+	})).Register("missing", RouteHandlerFunc(func(c *Context) {
+		if err := c.Send().File(filepath.Join(dir, "missing")); !os.IsNotExist(err) {
+			t.Fail()
+		}
+		c.Error404()
+	}))
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	get := func(path, ranges string) (*http.Response, string) {
+		req, _ := http.NewRequest("GET", ts.URL+path, nil)
+		if ranges != "" {
+			req.Header.Set("Range", ranges)
+		}
+		res, err := http.DefaultClient.Do(req)
+		Check(err)
+		b, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		return res, string(b)
+	}
+
+	res, body := get("/bytes", "")
+	if body != "0123456789" || res.ContentLength != 10 || res.Header.Get("Content-Encoding") != "" {
+		t.Fail()
+	}
+
+	res, body = get("/bytes", "bytes=2-4")
+	if res.StatusCode != 206 || body != "234" || res.Header.Get("Content-Range") != "bytes 2-4/10" ||
+		res.Header.Get("Content-Disposition") != `attachment; filename="data.csv"` || !strings.HasPrefix(res.Header.Get("Content-Type"), "text/csv") {
+		t.Fail()
+	}
+
+	res, body = get("/bytes", "bytes=0-1,8-9")
+	if res.StatusCode != 206 || !strings.HasPrefix(res.Header.Get("Content-Type"), "multipart/byteranges") ||
+		!strings.Contains(body, "01") || !strings.Contains(body, "89") {
+		t.Fail()
+	}
+
+	start := time.Now()
+	res, body = get("/file", "")
+	if res.StatusCode != 200 || body != "0123456789" || res.Header.Get("Content-Disposition") != `inline; filename="data.txt"` ||
+		res.Header.Get("Last-Modified") == "" {
+		t.Fail()
+	}
+	if time.Since(start) < 5*time.Millisecond {
+		t.Fail()
+	}
+
+	if res, _ = get("/missing", ""); res.StatusCode != 404 {
+		t.Fail()
+	}
+}
@@ -0,0 +1,357 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Server-Sent Event
+type SSEEvent struct {
+	Id    string
+	Event string
+	Data  string
+	// Reconnection time, zero is omitted
+	Retry time.Duration
+}
+
+// Write Event in text/event-stream format
+func (e SSEEvent) WriteTo(w io.Writer) (int64, error) {
+	buf := &bytes.Buffer{}
+	if e.Id != "" {
+		fmt.Fprintf(buf, "id: %s\n", sseClean(e.Id))
+	}
+	if e.Event != "" {
+		fmt.Fprintf(buf, "event: %s\n", sseClean(e.Event))
+	}
+	if e.Retry > 0 {
+		fmt.Fprintf(buf, "retry: %d\n", e.Retry/time.Millisecond)
+	}
+	for _, line := range strings.Split(strings.Replace(e.Data, "\r\n", "\n", -1), "\n") {
+		fmt.Fprintf(buf, "data: %s\n", line)
+	}
+	buf.WriteString("\n")
+	return buf.WriteTo(w)
+}
+
+// Fields other than data must be single line
+func sseClean(s string) string {
+	return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+}
+
+type sseState struct {
+	sync.Mutex
+	started bool
+}
+
+// Server-Sent Events Stream
+type SSE struct {
+	c *Context
+}
+
+// Server-Sent Events Stream
+func (c *Context) SSE() SSE {
+	if c.pri.sse == nil {
+		c.pri.sse = &sseState{}
+	}
+	return SSE{c}
+}
+
+func (s SSE) start() {
+	if s.c.pri.sse.started {
+		return
+	}
+	s.c.pri.sse.started = true
+
+	header := s.c.Res.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	// Disable proxy buffering (nginx)
+	header.Set("X-Accel-Buffering", "no")
+	// Compression would buffer events, gzip writer is bypassed.
+	header.Del("Content-Encoding")
+
+	s.c.Res.WriteHeader(s.c.Pub.Status)
+	s.c.Res.Flush()
+}
+
+// Send headers, called automatically by Send and Comment.
+func (s SSE) Start() {
+	s.c.pri.sse.Lock()
+	defer s.c.pri.sse.Unlock()
+	s.start()
+}
+
+func (s SSE) write(wt io.WriterTo) error {
+	s.c.pri.sse.Lock()
+	defer s.c.pri.sse.Unlock()
+	s.start()
+	if _, err := wt.WriteTo(s.c.Res); err != nil {
+		return err
+	}
+	s.c.Res.Flush()
+	return nil
+}
+
+// Send Event and Flush
+func (s SSE) Send(e SSEEvent) error {
+	return s.write(e)
+}
+
+// Send Data with Event name
+func (s SSE) SendData(event, data string) error {
+	return s.Send(SSEEvent{Event: event, Data: data})
+}
+
+// Send v as Json Data with Event name
+func (s SSE) SendJson(event string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.Send(SSEEvent{Event: event, Data: string(b)})
+}
+
+// Send Comment, ignored by the client, useful as keep alive.
+func (s SSE) Comment(text string) error {
+	buf := &bytes.Buffer{}
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(buf, ": %s\n", line)
+	}
+	buf.WriteString("\n")
+	return s.write(buf)
+}
+
+// Set client reconnection time
+func (s SSE) Retry(d time.Duration) error {
+	return s.write(bytes.NewBufferString(fmt.Sprintf("retry: %d\n\n", d/time.Millisecond)))
+}
+
+// Get Last-Event-ID sent by reconnecting client
+func (s SSE) LastEventId() string {
+	if id := s.c.Req.Header.Get("Last-Event-Id"); id != "" {
+		return id
+	}
+	return s.c.Req.URL.Query().Get("lastEventId")
+}
+
+// Closed when client disconnects
+func (s SSE) Done() <-chan struct{} {
+	return s.c.Req.Context().Done()
+}
+
+// Send heartbeat comment every interval until stop is called or client disconnects.
+// Stop waits for the pending heartbeat, call it before returning from the handler.
+func (s SSE) Heartbeat(interval time.Duration) (stop func()) {
+	quit := make(chan struct{})
+	done := make(chan struct{})
+	var once sync.Once
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if s.Comment("heartbeat") != nil {
+					return
+				}
+			case <-quit:
+				return
+			case <-s.Done():
+				return
+			}
+		}
+	}()
+	return func() {
+		once.Do(func() {
+			close(quit)
+		})
+		<-done
+	}
+}
+
+// Event Buffer for Last-Event-ID replay
+type SSEBuffer interface {
+	// Store event, assigns Id if blank and returns the stored event.
+	Add(topic string, e SSEEvent) SSEEvent
+	// Events of topic after lastId, nil if lastId is unknown.
+	Since(topic, lastId string) []SSEEvent
+}
+
+// In-memory ring buffer, keeps the last Size events per topic. Ids are sequential numbers.
+type SSEMemoryBuffer struct {
+	sync.Mutex
+	Size   int
+	seq    uint64
+	topics map[string][]SSEEvent
+}
+
+// Construct New In-memory Event Buffer
+func NewSSEMemoryBuffer(size int) *SSEMemoryBuffer {
+	return &SSEMemoryBuffer{Size: size, topics: map[string][]SSEEvent{}}
+}
+
+func (b *SSEMemoryBuffer) Add(topic string, e SSEEvent) SSEEvent {
+	b.Lock()
+	defer b.Unlock()
+
+	b.seq++
+	if e.Id == "" {
+		e.Id = strconv.FormatUint(b.seq, 10)
+	}
+
+	events := append(b.topics[topic], e)
+	if len(events) > b.Size {
+		events = events[len(events)-b.Size:]
+	}
+	b.topics[topic] = events
+
+	return e
+}
+
+func (b *SSEMemoryBuffer) Since(topic, lastId string) []SSEEvent {
+	b.Lock()
+	defer b.Unlock()
+
+	events := b.topics[topic]
+	for i, e := range events {
+		if e.Id == lastId {
+			return append([]SSEEvent{}, events[i+1:]...)
+		}
+	}
+	return nil
+}
+
+// Fan out events to subscribers by topic.
+type SSEBroker struct {
+	sync.RWMutex
+	// Optional, enables Last-Event-ID replay
+	Buffer SSEBuffer
+	// Heartbeat interval of Serve, zero disables
+	Heartbeat time.Duration
+	// Events queued per subscriber, slow subscribers are disconnected and may replay.
+	QueueSize int
+	subs      map[string]map[chan SSEEvent]bool
+}
+
+// Construct New Broker, buffer may be nil.
+func NewSSEBroker(buffer SSEBuffer) *SSEBroker {
+	return &SSEBroker{
+		Buffer:    buffer,
+		Heartbeat: 30 * time.Second,
+		QueueSize: 64,
+		subs:      map[string]map[chan SSEEvent]bool{},
+	}
+}
+
+// Publish Event to topic, returns the event with Id assigned by Buffer.
+func (b *SSEBroker) Publish(topic string, e SSEEvent) SSEEvent {
+	b.Lock()
+	defer b.Unlock()
+
+	if b.Buffer != nil {
+		e = b.Buffer.Add(topic, e)
+	}
+
+	for ch := range b.subs[topic] {
+		select {
+		case ch <- e:
+		default:
+			// Too slow, client reconnects with Last-Event-ID
+			b.unsubscribe(topic, ch)
+		}
+	}
+	return e
+}
+
+// Subscribe to topic, channel is closed on cancel or when the subscriber falls behind.
+func (b *SSEBroker) Subscribe(topic string) (events <-chan SSEEvent, cancel func()) {
+	b.Lock()
+	defer b.Unlock()
+
+	ch := make(chan SSEEvent, b.QueueSize)
+	if b.subs[topic] == nil {
+		b.subs[topic] = map[chan SSEEvent]bool{}
+	}
+	b.subs[topic][ch] = true
+
+	return ch, func() {
+		b.Lock()
+		defer b.Unlock()
+		b.unsubscribe(topic, ch)
+	}
+}
+
+func (b *SSEBroker) unsubscribe(topic string, ch chan SSEEvent) {
+	if !b.subs[topic][ch] {
+		return
+	}
+	delete(b.subs[topic], ch)
+	if len(b.subs[topic]) == 0 {
+		delete(b.subs, topic)
+	}
+	close(ch)
+}
+
+// Number of subscribers of topic
+func (b *SSEBroker) Subscribers(topic string) int {
+	b.RLock()
+	defer b.RUnlock()
+	return len(b.subs[topic])
+}
+
+// Stream topic to client until it disconnects, missed events are replayed from Last-Event-ID.
+func (b *SSEBroker) Serve(c *Context, topic string) {
+	sse := c.SSE()
+
+	events, cancel := b.Subscribe(topic)
+	defer cancel()
+
+	sse.Start()
+
+	// Events published after Subscribe may be replayed and queued.
+	replayed := map[string]bool{}
+
+	if lastId := sse.LastEventId(); lastId != "" && b.Buffer != nil {
+		for _, e := range b.Buffer.Since(topic, lastId) {
+			if sse.Send(e) != nil {
+				return
+			}
+			replayed[e.Id] = true
+		}
+	}
+
+	if b.Heartbeat > 0 {
+		defer sse.Heartbeat(b.Heartbeat)()
+	}
+
+	for {
+		select {
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			if e.Id != "" && replayed[e.Id] {
+				continue
+			}
+			if sse.Send(e) != nil {
+				return
+			}
+		case <-sse.Done():
+			return
+		}
+	}
+}
+
+// Route Handler streaming topic, see Serve
+func (b *SSEBroker) Handler(topic string) RouteHandler {
+	return RouteHandlerFunc(func(c *Context) {
+		b.Serve(c, topic)
+	})
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSSEEvent(t *testing.T) {
+	buf := &strings.Builder{}
+	SSEEvent{Id: "1", Event: "up\ndate", Data: "a\nb", Retry: time.Second}.WriteTo(buf)
+
+	if buf.String() != "id: 1\nevent: update\nretry: 1000\ndata: a\ndata: b\n\n" {
+		t.Fail()
+	}
+}
+
+func TestSSEBroker(t *testing.T) {
+	App := NewApp()
+
+	broker := NewSSEBroker(NewSSEMemoryBuffer(10))
+	broker.Heartbeat = 0
+
+	App.DefaultRouter = broker.Handler("news")
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	broker.Publish("news", SSEEvent{Data: "one"})
+	broker.Publish("news", SSEEvent{Data: "two"})
+
+	req, _ := http.NewRequest("GET", ts.URL, nil)
+	req.Header.Set("Last-Event-ID", "1")
+	res, err := http.DefaultClient.Do(req)
+	Check(err)
+
+	if res.Header.Get("Content-Type") != "text/event-stream" || res.Header.Get("Cache-Control") != "no-cache" {
+		t.Fail()
+	}
+
+	br := bufio.NewReader(res.Body)
+	readEvent := func() string {
+		event := ""
+		for {
+			line, err := br.ReadString('\n')
+			if err != nil || line == "\n" {
+				return event
+			}
+			event += line
+		}
+	}
+
+	if readEvent() != "id: 2\ndata: two\n" {
+		t.Fail()
+	}
+
+	for broker.Subscribers("news") == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	broker.Publish("news", SSEEvent{Event: "flash", Data: "three"})
+
+	if readEvent() != "id: 3\nevent: flash\ndata: three\n" {
+		t.Fail()
+	}
+
+	res.Body.Close()
+
+	for i := 0; broker.Subscribers("news") != 0; i++ {
+		if i > 1000 {
+			t.Fatal("subscriber not removed on disconnect")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,336 @@
+package core
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"html"
+	"html/template"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Fingerprinted file name, e.g. app.3f2a9c1b0d4e.js or app-3f2a9c1b.css
+var staticFingerprint = regexp.MustCompile(`[.-]([0-9a-f]{8,64})(\.[^./]+)$`)
+
+// Hidden path segments allowed by StaticServer
+var StaticAllowHidden = []string{".well-known"}
+
+// Static File Server, implement RouteHandler and http.Handler.
+type StaticServer struct {
+	Root http.FileSystem
+	// Allow directory listing, default false
+	Listing bool
+	// Served for directories, blank disables
+	Index string
+	// Serve .br and .gz siblings when accepted by the client, default true
+	Precompressed bool
+	// Cache-Control max-age for names without fingerprint, zero sends "no-cache"
+	MaxAge time.Duration
+	// Cache-Control max-age for fingerprinted names, sent with immutable. Default one year.
+	// Only names from Assets (hash checked against the content) are fingerprinted,
+	// unless TrustFingerprints is set.
+	FingerprintMaxAge time.Duration
+	// Treat existing names that look fingerprinted (e.g. from a build tool) as immutable, default false
+	TrustFingerprints bool
+
+	assetsOnce sync.Once
+	assets     *Assets
+}
+
+// Construct New Static Server serving directory
+func NewStaticServer(dir string) *StaticServer {
+	return NewStaticServerFS(http.Dir(dir))
+}
+
+// Construct New Static Server serving file system
+func NewStaticServerFS(root http.FileSystem) *StaticServer {
+	return &StaticServer{
+		Root:              root,
+		Index:             "index.html",
+		Precompressed:     true,
+		FingerprintMaxAge: 365 * 24 * time.Hour,
+	}
+}
+
+func staticHidden(name string) bool {
+segments:
+	for _, segment := range strings.Split(name, "/") {
+		if !strings.HasPrefix(segment, ".") {
+			continue
+		}
+		for _, allow := range StaticAllowHidden {
+			if segment == allow {
+				continue segments
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Encodings accepted by the client, q=0 excluded.
+func acceptsEncoding(r *http.Request, encoding string) bool {
+	for _, value := range r.Header["Accept-Encoding"] {
+		for _, item := range strings.Split(value, ",") {
+			params := strings.Split(item, ";")
+			if strings.TrimSpace(params[0]) != encoding {
+				continue
+			}
+			for _, param := range params[1:] {
+				param = strings.Replace(param, " ", "", -1)
+				if param == "q=0" || strings.HasPrefix(param, "q=0.0") && strings.Trim(param[4:], "0") == "" {
+					return false
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func (s *StaticServer) open(name string) (http.File, os.FileInfo, error) {
+	file, err := s.Root.Open(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+	return file, info, nil
+}
+
+// Serve upath, returns status code on failure or zero when served.
+func (s *StaticServer) serve(w http.ResponseWriter, r *http.Request, upath string) int {
+	if r.Method != "GET" && r.Method != "HEAD" {
+		w.Header().Set("Allow", "GET, HEAD")
+		return http.StatusMethodNotAllowed
+	}
+
+	name := path.Clean("/" + upath)
+	if staticHidden(name) {
+		return http.StatusNotFound
+	}
+
+	file, info, err := s.open(name)
+	fingerprinted := err == nil && s.TrustFingerprints && staticFingerprint.MatchString(name)
+	if err != nil && os.IsNotExist(err) {
+		// app.3f2a9c1b.js is served from app.js, immutable if the hash matches the content
+		if m := staticFingerprint.FindStringSubmatchIndex(name); m != nil {
+			original := name[:m[0]] + name[m[4]:]
+			if file, info, err = s.open(original); err == nil {
+				fingerprinted = s.verifyFingerprint(original, name[m[2]:m[3]])
+				name = original
+			}
+		}
+	}
+	if err != nil {
+		if os.IsPermission(err) {
+			return http.StatusForbidden
+		}
+		return http.StatusNotFound
+	}
+	defer file.Close()
+
+	if info.IsDir() {
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			http.Redirect(w, r, path.Base(r.URL.Path)+"/", http.StatusMovedPermanently)
+			return 0
+		}
+		if s.Index != "" {
+			if index, indexInfo, err := s.open(path.Join(name, s.Index)); err == nil {
+				defer index.Close()
+				file, info, name = index, indexInfo, path.Join(name, s.Index)
+				goto serve
+			}
+		}
+		if !s.Listing {
+			return http.StatusNotFound
+		}
+		listing := *r
+		u := *r.URL
+		u.Path = strings.TrimRight(name, "/") + "/"
+		listing.URL = &u
+		http.FileServer(s.Root).ServeHTTP(w, &listing)
+		return 0
+	}
+
+serve:
+	header := w.Header()
+
+	if fingerprinted {
+		header.Set("Cache-Control", "public, max-age="+cacheSeconds(s.FingerprintMaxAge)+", immutable")
+	} else if s.MaxAge > 0 {
+		header.Set("Cache-Control", "public, max-age="+cacheSeconds(s.MaxAge))
+	} else {
+		header.Set("Cache-Control", "no-cache")
+	}
+
+	ctype := mime.TypeByExtension(path.Ext(name))
+	if ctype == "" {
+		ctype = "application/octet-stream"
+	}
+	header.Set("Content-Type", ctype)
+	header.Del("Content-Encoding")
+
+	var content io.ReadSeeker = file
+	modTime := info.ModTime()
+
+	if s.Precompressed {
+		header.Add("Vary", "Accept-Encoding")
+		for _, enc := range []struct{ name, ext string }{{"br", ".br"}, {"gzip", ".gz"}} {
+			if !acceptsEncoding(r, enc.name) {
+				continue
+			}
+			compressed, compressedInfo, err := s.open(name + enc.ext)
+			if err != nil || compressedInfo.IsDir() {
+				continue
+			}
+			defer compressed.Close()
+			content, modTime = compressed, compressedInfo.ModTime()
+			header.Set("Content-Encoding", enc.name)
+			break
+		}
+	}
+
+	http.ServeContent(w, r, name, modTime, content)
+	return 0
+}
+
+// Implement http.Handler
+func (s *StaticServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if status := s.serve(w, r, r.URL.Path); status != 0 {
+		http.Error(w, http.StatusText(status), status)
+	}
+}
+
+// Is hash the Assets hash of name
+func (s *StaticServer) verifyFingerprint(name, hash string) bool {
+	s.assetsOnce.Do(func() {
+		s.assets = NewAssetsFS("", s.Root)
+	})
+	sum, err := s.assets.hash(name)
+	return err == nil && sum == hash
+}
+
+// Implement RouteHandler, path after the matched route is served.
+func (s *StaticServer) View(c *Context) {
+	if status := s.serve(c.Res, c.Req, strings.TrimPrefix(c.Req.URL.Path, c.pri.curpath)); status != 0 {
+		c.Error(status, nil)
+	}
+}
+
+// Map logical asset paths to content hashed URLs, e.g. "app.js" to "/static/app.3f2a9c1b0d4e.js".
+type Assets struct {
+	sync.Mutex
+	// URL Prefix, e.g. "/static"
+	Prefix string
+	Root   http.FileSystem
+	hashes map[string]assetHash
+}
+
+type assetHash struct {
+	modTime time.Time
+	size    int64
+	hash    string
+}
+
+// Construct New Assets from directory
+func NewAssets(prefix, dir string) *Assets {
+	return NewAssetsFS(prefix, http.Dir(dir))
+}
+
+// Construct New Assets from file system
+func NewAssetsFS(prefix string, root http.FileSystem) *Assets {
+	return &Assets{Prefix: strings.TrimRight(prefix, "/"), Root: root, hashes: map[string]assetHash{}}
+}
+
+func (a *Assets) hash(name string) (string, error) {
+	file, err := a.Root.Open(name)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	a.Lock()
+	cached, ok := a.hashes[name]
+	a.Unlock()
+
+	if ok && cached.modTime.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.hash, nil
+	}
+
+	h := sha1.New()
+	if _, err = io.Copy(h, file); err != nil {
+		return "", err
+	}
+	hash := hex.EncodeToString(h.Sum(nil))[:12]
+
+	a.Lock()
+	a.hashes[name] = assetHash{info.ModTime(), info.Size(), hash}
+	a.Unlock()
+
+	return hash, nil
+}
+
+// Get hashed URL, falls back to unhashed URL if file can not be read.
+func (a *Assets) URL(name string) string {
+	name = path.Clean("/" + name)
+	hash, err := a.hash(name)
+	if err != nil {
+		return a.Prefix + name
+	}
+	ext := path.Ext(name)
+	return a.Prefix + strings.TrimSuffix(name, ext) + "." + hash + ext
+}
+
+// Template Functions: asset (URL), stylesheet and script (HTML tags)
+func (a *Assets) FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"asset": a.URL,
+		"stylesheet": func(name string) template.HTML {
+			return template.HTML(a.Stylesheet(name))
+		},
+		"script": func(name string) template.HTML {
+			return template.HTML(a.Script(name))
+		},
+	}
+}
+
+// Get stylesheet link tag
+func (a *Assets) Stylesheet(name string) string {
+	return `<link rel="stylesheet" href="` + html.EscapeString(a.URL(name)) + `">`
+}
+
+// Get deferred script tag
+func (a *Assets) Script(name string) string {
+	return `<script src="` + html.EscapeString(a.URL(name)) + `" defer></script>`
+}
+
+// MethodHtml5 hook, adds stylesheet (.css) and script (other) tags to Head.
+//
+//	me.RegOnInitFunc(assets.Head("app.css", "app.js"))
+func (a *Assets) Head(names ...string) func(HtmlPrinter, *Context) {
+	return func(h HtmlPrinter, c *Context) {
+		for _, name := range names {
+			if path.Ext(name) == ".css" {
+				h.HeadLn(a.Stylesheet(name))
+				continue
+			}
+			h.HeadLn(a.Script(name))
+		}
+	}
+}
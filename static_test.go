@@ -0,0 +1,111 @@
+package core
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStaticServer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "core-static")
+	Check(err)
+	defer os.RemoveAll(dir)
+
+	write := func(name, content string) {
+		Check(os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		Check(ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	write("app.js", "console.log(1)")
+	write("app.js.gz", "GZIP")
+	write(".env", "SECRET")
+	write("css/site.css", "body{}")
+	write("docs/index.html", "<h1>docs</h1>")
+	write("report-20240101.pdf", "PDF")
+
+	App := NewApp()
+	App.Static("/static/", dir)
+
+	assets := NewAssets("/static", dir)
+
+	App.DefaultRouter = NewDirRouter().Register("files", FileServer(dir))
+
+	ts := httptest.NewServer(App.mux)
+	defer ts.Close()
+
+	get := func(path, encoding string) (*http.Response, string) {
+		req, _ := http.NewRequest("GET", ts.URL+path, nil)
+		if encoding == "" {
+			// Transport adds gzip to a blank Accept-Encoding
+			encoding = "identity"
+		}
+		req.Header.Set("Accept-Encoding", encoding)
+		res, err := http.DefaultTransport.RoundTrip(req)
+		Check(err)
+		b, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+		return res, string(b)
+	}
+
+	for _, prefix := range []string{"/static", "/files"} {
+		if res, body := get(prefix+"/app.js", ""); res.StatusCode != 200 || body != "console.log(1)" ||
+			res.Header.Get("Cache-Control") != "no-cache" || !strings.Contains(res.Header.Get("Content-Type"), "javascript") {
+			t.Fail()
+		}
+
+		if res, body := get(prefix+"/app.js", "gzip, br;q=0"); body != "GZIP" || res.Header.Get("Content-Encoding") != "gzip" {
+			t.Fail()
+		}
+
+		if res, _ := get(prefix+"/.env", ""); res.StatusCode != 404 {
+			t.Fail()
+		}
+
+		if res, _ := get(prefix+"/css/", ""); res.StatusCode != 404 {
+			t.Fail()
+		}
+
+		if _, body := get(prefix+"/docs/", ""); body != "<h1>docs</h1>" {
+			t.Fail()
+		}
+	}
+
+	url := assets.URL("css/site.css")
+	if !strings.HasPrefix(url, "/static/css/site.") || len(url) != len("/static/css/site.123456789012.css") {
+		t.Fail()
+	}
+
+	if res, body := get(url, ""); body != "body{}" || !strings.Contains(res.Header.Get("Cache-Control"), "immutable") {
+		t.Fail()
+	}
+
+	// Hash not matching the content is served without immutable
+	if res, body := get("/static/css/site.deadbeef0000.css", ""); body != "body{}" || res.Header.Get("Cache-Control") != "no-cache" {
+		t.Fail()
+	}
+
+	// Names that only look fingerprinted need TrustFingerprints
+	if res, _ := get("/static/report-20240101.pdf", ""); res.Header.Get("Cache-Control") != "no-cache" {
+		t.Fail()
+	}
+
+	trusted := NewStaticServer(dir)
+	trusted.TrustFingerprints = true
+	res := httptest.NewRecorder()
+	trusted.ServeHTTP(res, httptest.NewRequest("GET", "/report-20240101.pdf", nil))
+	if !strings.Contains(res.Header().Get("Cache-Control"), "immutable") {
+		t.Fail()
+	}
+
+	if assets.Stylesheet("css/site.css") != `<link rel="stylesheet" href="`+url+`">` {
+		t.Fail()
+	}
+
+	if assets.URL("missing.js") != "/static/missing.js" {
+		t.Fail()
+	}
+}
@@ -1,38 +1,71 @@
-package core
-
-import (
-	"time"
-)
-
-// Set Timezone on global level
-func (app *App) SetTimeZone(zone string) {
-	var err error
-	app.TimeLoc, err = time.LoadLocation(zone)
-	Check(err)
-}
-
-// Get Current Time
-func CurTime() time.Time {
-	return time.Now()
-}
-
-// Time
-type Time struct {
-	c *Context
-}
-
-func (c *Context) Time() Time {
-	return Time{c}
-}
-
-// Set Timezone on user request level
-func (t Time) SetZone(zone string) {
-	var err error
-	t.c.Pub.TimeLoc, err = time.LoadLocation(zone)
-	t.c.Check(err)
-}
-
-// Get Current Time
-func (t Time) Now() time.Time {
-	return CurTime()
-}
+package core
+
+import (
+	"time"
+)
+
+// Set Timezone on global level
+func (app *App) SetTimeZone(zone string) {
+	var err error
+	app.TimeLoc, err = time.LoadLocation(zone)
+	Check(err)
+}
+
+// Get Current Time
+func CurTime() time.Time {
+	return time.Now()
+}
+
+// Time
+type Time struct {
+	c *Context
+}
+
+func (c *Context) Time() Time {
+	return Time{c}
+}
+
+// Set Timezone on user request level
+func (t Time) SetZone(zone string) {
+	t.c.Check(t.TrySetZone(zone))
+}
+
+// Set Timezone on user request level, invalid zone returns error and keeps the current one.
+// Zone is loaded with time.LoadLocation (e.g. "UTC", "Local"), use LoadTimeZone for user input.
+func (t Time) TrySetZone(zone string) error {
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return err
+	}
+	t.c.Pub.TimeLoc = loc
+	return nil
+}
+
+// Get Current Time
+func (t Time) Now() time.Time {
+	return CurTime()
+}
+
+// Convert t to user Timezone
+func (t Time) In(tm time.Time) time.Time {
+	return tm.In(t.c.Pub.TimeLoc)
+}
+
+// Parse value in user Timezone and convert to UTC, layouts default to AutoBindTimeFormats.
+func (t Time) ParseUTC(value string, layouts ...string) (time.Time, error) {
+	if len(layouts) == 0 {
+		layouts = AutoBindTimeFormats
+	}
+	for _, layout := range layouts {
+		if tm, err := time.ParseInLocation(layout, value, t.c.Pub.TimeLoc); err == nil {
+			return tm.UTC(), nil
+		}
+	}
+	return time.Time{}, ErrorStr("Invalid time: " + value)
+}
+
+// Parse form field in user Timezone and convert to UTC, see ParseUTC
+func (t Time) FormUTC(name string, layouts ...string) (time.Time, error) {
+	t.c.Req.ParseMultipartForm(t.c.App.FormMemoryLimit)
+	return t.ParseUTC(t.c.Req.FormValue(name), layouts...)
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// IANA zone names, e.g. "Europe/London", "America/Argentina/Buenos_Aires" or "Etc/GMT+5"
+var timeZoneName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_+\-]*(/[A-Za-z0-9_+\-]+){0,2}$`)
+
+var timeZoneCache = struct {
+	sync.RWMutex
+	m map[string]*time.Location
+}{m: map[string]*time.Location{}}
+
+// Load time zone by IANA name, valid zones are cached.
+// Names are validated first, so user input can be passed safely.
+func LoadTimeZone(name string) (*time.Location, error) {
+	timeZoneCache.RLock()
+	loc := timeZoneCache.m[name]
+	timeZoneCache.RUnlock()
+	if loc != nil {
+		return loc, nil
+	}
+
+	if len(name) > 64 || !timeZoneName.MatchString(name) || name == "Local" {
+		return nil, ErrorStr("Invalid time zone: " + name)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+
+	timeZoneCache.Lock()
+	timeZoneCache.m[name] = loc
+	timeZoneCache.Unlock()
+	return loc, nil
+}
+
+// Time Zone Detection, use as MiddlewareFunc (tz.Middleware).
+// Zone is resolved from header, cookie and session in that order, invalid zones are ignored
+// and c.Pub.TimeLoc stays App.TimeLoc. Zone sent by header is saved to cookie.
+type TimeZone struct {
+	// Request header, blank disables
+	Header string
+	// Cookie name, blank disables
+	Cookie string
+	// Session key of map[string]string or map[string]interface{} session, blank disables
+	SessionKey string
+}
+
+// Construct New Time Zone Detection, header "X-Time-Zone" and cookie "tz".
+func NewTimeZone() *TimeZone {
+	return &TimeZone{Header: "X-Time-Zone", Cookie: "tz"}
+}
+
+func (tz *TimeZone) cookie(c *Context) string {
+	if tz.Cookie == "" {
+		return ""
+	}
+	cookie, err := c.Cookie(tz.Cookie).Unsigned().Get()
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+func (tz *TimeZone) session(c *Context) string {
+	if tz.SessionKey == "" {
+		return ""
+	}
+	switch data := c.Pub.Session.(type) {
+	case map[string]string:
+		return data[tz.SessionKey]
+	case map[string]interface{}:
+		name, _ := data[tz.SessionKey].(string)
+		return name
+	}
+	return ""
+}
+
+// Resolve zone of request, returns nil if none is valid.
+func (tz *TimeZone) Resolve(c *Context) *time.Location {
+	cookie := tz.cookie(c)
+
+	if tz.Header != "" {
+		if name := c.Req.Header.Get(tz.Header); name != "" {
+			if loc, err := LoadTimeZone(name); err == nil {
+				if tz.Cookie != "" && cookie != name {
+					tz.Save(c, name)
+				}
+				return loc
+			}
+		}
+	}
+
+	for _, name := range []string{cookie, tz.session(c)} {
+		if name == "" {
+			continue
+		}
+		if loc, err := LoadTimeZone(name); err == nil {
+			return loc
+		}
+	}
+	return nil
+}
+
+// Save zone to cookie for a year
+func (tz *TimeZone) Save(c *Context, name string) {
+	c.Cookie(tz.Cookie).Unsigned().Value(name).Path("/").MaxAge(365 * 24 * 60 * 60).SaveRes()
+}
+
+// Implement MiddlewareFunc
+func (tz *TimeZone) Middleware(c *Context, next func()) {
+	if loc := tz.Resolve(c); loc != nil {
+		c.Pub.TimeLoc = loc
+	}
+	next()
+}
+
+// Script saving browser zone to cookie, applies from the next request.
+func (tz *TimeZone) Script() string {
+	return fmt.Sprintf(`<script>(function(){var n=%q,z=Intl.DateTimeFormat().resolvedOptions().timeZone;`+
+		`if(!z||(";"+document.cookie.replace(/ /g,"")+";").indexOf(";"+n+"="+z+";")!==-1)return;`+
+		`document.cookie=n+"="+z+";path=/;max-age=31536000;samesite=lax"})()</script>`,
+		tz.Cookie)
+}
+
+// MethodHtml5 hook, adds Script to BodyJs while the cookie is missing.
+func (tz *TimeZone) BodyJs() func(HtmlPrinter, *Context) {
+	return func(h HtmlPrinter, c *Context) {
+		if tz.cookie(c) == "" {
+			h.BodyJs(tz.Script())
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadTimeZone(t *testing.T) {
+	if loc, err := LoadTimeZone("Europe/London"); err != nil || loc.String() != "Europe/London" {
+		t.Fail()
+	}
+
+	for _, name := range []string{"../../etc/passwd", "Local", "", "Mars/Olympus"} {
+		if _, err := LoadTimeZone(name); err == nil {
+			t.Fail()
+		}
+	}
+}
+
+func TestTimeSetZone(t *testing.T) {
+	c := &Context{Pub: Public{TimeLoc: time.UTC}}
+
+	if c.Time().TrySetZone("Mars/Olympus") == nil || c.Pub.TimeLoc != time.UTC {
+		t.Fail()
+	}
+
+	c.Time().SetZone("Local")
+	if c.Pub.TimeLoc != time.Local {
+		t.Fail()
+	}
+
+	c.Time().SetZone("Europe/Paris")
+	if c.Pub.TimeLoc.String() != "Europe/Paris" {
+		t.Fail()
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fail()
+		}
+	}()
+	c.Time().SetZone("Mars/Olympus")
+}
+
+func TestTimeZone(t *testing.T) {
+	type Event struct {
+		Start time.Time `form:"start,utc"`
+	}
+
+	App := NewApp()
+	App.SetTimeZone("UTC")
+
+	tz := NewTimeZone()
+	App.Use(tz.Middleware)
+
+	App.DefaultRouter = NewDirRouter().Register("zone", RouteHandlerFunc(func(c *Context) {
+		c.Fmt().Print(c.Pub.TimeLoc.String())
+	})).Register("form", RouteHandlerFunc(func(c *Context) {
+		tm, err := c.Time().FormUTC("start")
+		if err != nil || !tm.Equal(time.Date(2021, 7, 1, 8, 30, 0, 0, time.UTC)) || tm.Location() != time.UTC {
+			t.Fail()
+		}
+		event := &Event{}
+		autoPopulateFields{}.do(c, reflect.ValueOf(event))
+		if !event.Start.Equal(tm) || event.Start.Location() != time.UTC {
+			t.Fail()
+		}
+		c.Fmt().Print("ok")
+	}))
+
+	serve := func(req *http.Request) *httptest.ResponseRecorder {
+		res := httptest.NewRecorder()
+		App.ServeHTTP(res, req)
+		return res
+	}
+
+	req := httptest.NewRequest("GET", "/zone", nil)
+	req.Header.Set("X-Time-Zone", "Asia/Tokyo")
+	res := serve(req)
+	if res.Body.String() != "Asia/Tokyo" || !strings.HasPrefix(res.Header().Get("Set-Cookie"), "tz=Asia/Tokyo") {
+		t.Fail()
+	}
+
+	req = httptest.NewRequest("GET", "/zone", nil)
+	req.AddCookie(&http.Cookie{Name: "tz", Value: "Europe/Paris"})
+	if res = serve(req); res.Body.String() != "Europe/Paris" || res.Header().Get("Set-Cookie") != "" {
+		t.Fail()
+	}
+
+	req = httptest.NewRequest("GET", "/zone", nil)
+	req.Header.Set("X-Time-Zone", "Nowhere/Invalid")
+	if res = serve(req); res.Body.String() != "UTC" {
+		t.Fail()
+	}
+
+	req = httptest.NewRequest("POST", "/form", strings.NewReader("start=2021-07-01T10:30"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(&http.Cookie{Name: "tz", Value: "Europe/Paris"})
+	if res = serve(req); res.Body.String() != "ok" {
+		t.Fail()
+	}
+
+	if !strings.Contains(tz.Script(), `var n="tz"`) {
+		t.Fail()
+	}
+}
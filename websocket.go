@@ -0,0 +1,660 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// WebSocket Message Types (Opcodes)
+const (
+	wsContinuation = 0
+	WsText         = 1
+	WsBinary       = 2
+	WsClose        = 8
+	WsPing         = 9
+	WsPong         = 10
+)
+
+// WebSocket Close Codes
+const (
+	WsCloseNormal          = 1000
+	WsCloseGoingAway       = 1001
+	WsCloseProtocolError   = 1002
+	WsCloseUnsupported     = 1003
+	WsCloseNoStatus        = 1005
+	WsCloseInvalidPayload  = 1007
+	WsClosePolicyViolation = 1008
+	WsCloseTooBig          = 1009
+	WsCloseInternal        = 1011
+)
+
+// Default maximum message size in bytes, see WsConn.SetReadLimit
+const WsDefaultReadLimit = 1024 * 1024
+
+const wsGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+var (
+	ErrWsClosed     = errors.New("core: websocket connection closed")
+	ErrWsReadLimit  = errors.New("core: websocket message exceeds read limit")
+	ErrWsBadOpcode  = errors.New("core: websocket invalid message type")
+	ErrWsWriterOpen = errors.New("core: websocket previous writer not closed")
+)
+
+// Close frame received from, or sent to, the peer.
+type WsCloseError struct {
+	Code   int
+	Reason string
+}
+
+func (e *WsCloseError) Error() string {
+	return fmt.Sprint("core: websocket closed ", e.Code, " ", e.Reason)
+}
+
+// Check if header contains token, comma separated and case insensitive.
+func headerHasToken(header http.Header, name, token string) bool {
+	for _, value := range header[http.CanonicalHeaderKey(name)] {
+		for _, t := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(t), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WebSocket Handshake Options
+type WsUpgrader struct {
+	// Subprotocols supported by the server in order of preference.
+	Subprotocols []string
+	// Return true to accept Origin, nil accepts same host or no Origin header.
+	CheckOrigin func(c *Context) bool
+	// Negotiate permessage-deflate (no context takeover).
+	Compression bool
+	// Maximum message size in bytes, default WsDefaultReadLimit
+	ReadLimit int64
+}
+
+func wsSameOrigin(c *Context) bool {
+	origin := c.Req.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Host, c.Req.Host)
+}
+
+// Accept permessage-deflate offer, unless the client requires a smaller server window.
+func wsAcceptDeflate(header http.Header) bool {
+	for _, value := range header["Sec-Websocket-Extensions"] {
+	offers:
+		for _, offer := range strings.Split(value, ",") {
+			params := strings.Split(offer, ";")
+			if strings.TrimSpace(params[0]) != "permessage-deflate" {
+				continue
+			}
+			for _, param := range params[1:] {
+				param = strings.TrimSpace(param)
+				if strings.HasPrefix(param, "server_max_window_bits") && param != "server_max_window_bits=15" {
+					continue offers
+				}
+			}
+			return true
+		}
+	}
+	return false
+}
+
+func wsAccept(key string) string {
+	h := sha1.New()
+	io.WriteString(h, key+wsGUID)
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// Upgrade Request to WebSocket, on failure error response is sent through c.HandleError.
+// The context is terminated on success, the connection is owned by the caller.
+func (u *WsUpgrader) Upgrade(c *Context) (*WsConn, error) {
+	fail := func(err *HTTPError) (*WsConn, error) {
+		c.HandleError(err)
+		return nil, err
+	}
+
+	if c.Req.Method != "GET" {
+		return fail(ErrMethodNotAllowed)
+	}
+
+	if !c.Is().WebSocketRequest() {
+		return fail(ErrBadRequest("websocket upgrade expected"))
+	}
+
+	if c.Req.Header.Get("Sec-Websocket-Version") != "13" {
+		c.Res.Header().Set("Sec-WebSocket-Version", "13")
+		return fail(NewHTTPError(426, "unsupported websocket version"))
+	}
+
+	key := c.Req.Header.Get("Sec-Websocket-Key")
+	if b, err := base64.StdEncoding.DecodeString(key); err != nil || len(b) != 16 {
+		return fail(ErrBadRequest("invalid websocket key"))
+	}
+
+	checkOrigin := u.CheckOrigin
+	if checkOrigin == nil {
+		checkOrigin = wsSameOrigin
+	}
+	if !checkOrigin(c) {
+		return fail(ErrForbidden("origin not allowed"))
+	}
+
+	subprotocol := ""
+	offered := c.Req.Header.Get("Sec-Websocket-Protocol")
+subprotocols:
+	for _, protocol := range u.Subprotocols {
+		for _, offer := range strings.Split(offered, ",") {
+			if strings.TrimSpace(offer) == protocol {
+				subprotocol = protocol
+				break subprotocols
+			}
+		}
+	}
+
+	compress := u.Compression && wsAcceptDeflate(c.Req.Header)
+
+	conn, brw, err := c.Res.Hijack()
+	if err != nil {
+		return fail(ErrInternal(err))
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n")
+	buf.WriteString("Sec-WebSocket-Accept: " + wsAccept(key) + "\r\n")
+	if subprotocol != "" {
+		buf.WriteString("Sec-WebSocket-Protocol: " + subprotocol + "\r\n")
+	}
+	if compress {
+		buf.WriteString("Sec-WebSocket-Extensions: permessage-deflate; server_no_context_takeover; client_no_context_takeover\r\n")
+	}
+	for name, values := range c.Res.Header() {
+		switch name {
+		case "Connection", "Upgrade", "Content-Encoding", "Content-Type", "Content-Length":
+			continue
+		}
+		for _, value := range values {
+			buf.WriteString(name + ": " + value + "\r\n")
+		}
+	}
+	buf.WriteString("\r\n")
+
+	if _, err = conn.Write(buf.Bytes()); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	c.Pub.Status = http.StatusSwitchingProtocols
+
+	readLimit := u.ReadLimit
+	if readLimit <= 0 {
+		readLimit = WsDefaultReadLimit
+	}
+
+	return &WsConn{
+		conn:             conn,
+		br:               brw.Reader,
+		bw:               bufio.NewWriter(conn),
+		subprotocol:      subprotocol,
+		compress:         compress,
+		writeCompression: compress,
+		readLimit:        readLimit,
+	}, nil
+}
+
+// Shortcut to (&WsUpgrader{}).Upgrade(c)
+func (c *Context) WsUpgrade() (*WsConn, error) {
+	return (&WsUpgrader{}).Upgrade(c)
+}
+
+// WebSocket Connection, message oriented.
+// One goroutine may read and any number may write concurrently.
+type WsConn struct {
+	conn net.Conn
+	br   *bufio.Reader
+	bw   *bufio.Writer
+
+	subprotocol      string
+	compress         bool
+	writeCompression bool
+	readLimit        int64
+
+	// Frame write lock and message write lock (held by NextWriter until Close)
+	wmu sync.Mutex
+	mmu sync.Mutex
+
+	closeSent bool
+	readErr   error
+
+	pingHandler func(data []byte) error
+	pongHandler func(data []byte) error
+}
+
+// Negotiated Subprotocol
+func (ws *WsConn) Subprotocol() string {
+	return ws.subprotocol
+}
+
+// Is permessage-deflate negotiated
+func (ws *WsConn) Compressed() bool {
+	return ws.compress
+}
+
+// Compress outgoing messages, only if negotiated.
+func (ws *WsConn) EnableWriteCompression(enable bool) {
+	ws.writeCompression = enable && ws.compress
+}
+
+// Set maximum message size in bytes, larger messages close the connection with WsCloseTooBig.
+func (ws *WsConn) SetReadLimit(limit int64) {
+	ws.readLimit = limit
+}
+
+// Set Ping Handler, default replies with pong.
+func (ws *WsConn) SetPingHandler(handler func(data []byte) error) {
+	ws.pingHandler = handler
+}
+
+// Set Pong Handler, default does nothing.
+func (ws *WsConn) SetPongHandler(handler func(data []byte) error) {
+	ws.pongHandler = handler
+}
+
+func (ws *WsConn) SetReadDeadline(t time.Time) error {
+	return ws.conn.SetReadDeadline(t)
+}
+
+func (ws *WsConn) SetWriteDeadline(t time.Time) error {
+	return ws.conn.SetWriteDeadline(t)
+}
+
+func (ws *WsConn) RemoteAddr() net.Addr {
+	return ws.conn.RemoteAddr()
+}
+
+func (ws *WsConn) writeFrame(fin, rsv1 bool, opcode int, payload []byte) error {
+	ws.wmu.Lock()
+	defer ws.wmu.Unlock()
+
+	if ws.closeSent {
+		return ErrWsClosed
+	}
+
+	b0 := byte(opcode)
+	if fin {
+		b0 |= 0x80
+	}
+	if rsv1 {
+		b0 |= 0x40
+	}
+
+	header := []byte{b0, 0}
+	length := len(payload)
+	switch {
+	case length <= 125:
+		header[1] = byte(length)
+	case length <= 0xffff:
+		header[1] = 126
+		header = append(header, 0, 0)
+		binary.BigEndian.PutUint16(header[2:], uint16(length))
+	default:
+		header[1] = 127
+		header = append(header, 0, 0, 0, 0, 0, 0, 0, 0)
+		binary.BigEndian.PutUint64(header[2:], uint64(length))
+	}
+
+	if opcode == WsClose {
+		ws.closeSent = true
+	}
+
+	ws.bw.Write(header)
+	ws.bw.Write(payload)
+	return ws.bw.Flush()
+}
+
+// Write Message, messageType is WsText or WsBinary.
+func (ws *WsConn) WriteMessage(messageType int, data []byte) error {
+	if messageType != WsText && messageType != WsBinary {
+		return ErrWsBadOpcode
+	}
+
+	ws.mmu.Lock()
+	defer ws.mmu.Unlock()
+
+	if ws.writeCompression {
+		compressed, err := wsDeflate(data)
+		if err != nil {
+			return err
+		}
+		return ws.writeFrame(true, true, messageType, compressed)
+	}
+	return ws.writeFrame(true, false, messageType, data)
+}
+
+// Write Text Message
+func (ws *WsConn) WriteText(text string) error {
+	return ws.WriteMessage(WsText, []byte(text))
+}
+
+// Write Value as Json Text Message
+func (ws *WsConn) WriteJson(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return ws.WriteMessage(WsText, b)
+}
+
+// Read Json Text or Binary Message into v
+func (ws *WsConn) ReadJson(v interface{}) error {
+	_, data, err := ws.ReadMessage()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// Write Ping
+func (ws *WsConn) WritePing(data []byte) error {
+	return ws.writeFrame(true, false, WsPing, data)
+}
+
+// Write Pong
+func (ws *WsConn) WritePong(data []byte) error {
+	return ws.writeFrame(true, false, WsPong, data)
+}
+
+// Write Close Frame, no more messages can be written afterward.
+func (ws *WsConn) WriteClose(code int, reason string) error {
+	payload := []byte{}
+	if code != WsCloseNoStatus {
+		payload = make([]byte, 2, 2+len(reason))
+		binary.BigEndian.PutUint16(payload, uint16(code))
+		payload = append(payload, reason...)
+	}
+	return ws.writeFrame(true, false, WsClose, payload)
+}
+
+// Send Close Frame (if not already sent) and close the connection.
+func (ws *WsConn) Close() error {
+	ws.WriteClose(WsCloseNormal, "")
+	return ws.conn.Close()
+}
+
+// Fragmented Message Writer, every Write is sent as one frame. Not compressed.
+type wsWriter struct {
+	ws     *WsConn
+	opcode int
+	closed bool
+}
+
+func (w *wsWriter) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, ErrWsClosed
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+	if err := w.ws.writeFrame(false, false, w.opcode, p); err != nil {
+		return 0, err
+	}
+	w.opcode = wsContinuation
+	return len(p), nil
+}
+
+func (w *wsWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	defer w.ws.mmu.Unlock()
+	return w.ws.writeFrame(true, false, w.opcode, nil)
+}
+
+// Get Writer for a fragmented message, other messages are held until the writer is closed.
+func (ws *WsConn) NextWriter(messageType int) (io.WriteCloser, error) {
+	if messageType != WsText && messageType != WsBinary {
+		return nil, ErrWsBadOpcode
+	}
+	ws.mmu.Lock()
+	return &wsWriter{ws: ws, opcode: messageType}, nil
+}
+
+type wsFrame struct {
+	fin     bool
+	rsv1    bool
+	opcode  int
+	payload []byte
+}
+
+// Fail connection with close code
+func (ws *WsConn) fail(code int, reason string) error {
+	ws.WriteClose(code, reason)
+	ws.conn.Close()
+	ws.readErr = &WsCloseError{code, reason}
+	return ws.readErr
+}
+
+func (ws *WsConn) readFrame(limit int64) (wsFrame, error) {
+	frame := wsFrame{}
+
+	header := make([]byte, 2, 8)
+	if _, err := io.ReadFull(ws.br, header); err != nil {
+		return frame, err
+	}
+
+	frame.fin = header[0]&0x80 != 0
+	frame.rsv1 = header[0]&0x40 != 0
+	frame.opcode = int(header[0] & 0x0f)
+
+	if header[0]&0x30 != 0 {
+		return frame, ws.fail(WsCloseProtocolError, "reserved bits set")
+	}
+
+	if header[1]&0x80 == 0 {
+		return frame, ws.fail(WsCloseProtocolError, "client frame not masked")
+	}
+
+	length := int64(header[1] & 0x7f)
+	switch length {
+	case 126:
+		if _, err := io.ReadFull(ws.br, header[:2]); err != nil {
+			return frame, err
+		}
+		length = int64(binary.BigEndian.Uint16(header[:2]))
+	case 127:
+		header = header[:8]
+		if _, err := io.ReadFull(ws.br, header); err != nil {
+			return frame, err
+		}
+		length = int64(binary.BigEndian.Uint64(header))
+		if length < 0 {
+			return frame, ws.fail(WsCloseProtocolError, "invalid length")
+		}
+	}
+
+	if frame.opcode >= WsClose {
+		if !frame.fin || length > 125 {
+			return frame, ws.fail(WsCloseProtocolError, "invalid control frame")
+		}
+	} else if length > limit {
+		return frame, ws.fail(WsCloseTooBig, "")
+	}
+
+	mask := make([]byte, 4)
+	if _, err := io.ReadFull(ws.br, mask); err != nil {
+		return frame, err
+	}
+
+	frame.payload = make([]byte, length)
+	if _, err := io.ReadFull(ws.br, frame.payload); err != nil {
+		return frame, err
+	}
+
+	for i := range frame.payload {
+		frame.payload[i] ^= mask[i%4]
+	}
+
+	return frame, nil
+}
+
+func wsValidCloseCode(code int) bool {
+	switch {
+	case code >= 1000 && code <= 1003, code >= 1007 && code <= 1014, code >= 3000 && code <= 4999:
+		return true
+	}
+	return false
+}
+
+func (ws *WsConn) readClose(payload []byte) error {
+	code, reason := WsCloseNoStatus, ""
+	switch {
+	case len(payload) == 1:
+		return ws.fail(WsCloseProtocolError, "invalid close payload")
+	case len(payload) >= 2:
+		code = int(binary.BigEndian.Uint16(payload))
+		reason = string(payload[2:])
+		if !wsValidCloseCode(code) {
+			return ws.fail(WsCloseProtocolError, "invalid close code")
+		}
+		if !utf8.ValidString(reason) {
+			return ws.fail(WsCloseInvalidPayload, "")
+		}
+	}
+
+	ws.WriteClose(code, "")
+	ws.conn.Close()
+	ws.readErr = &WsCloseError{code, reason}
+	return ws.readErr
+}
+
+// Read Message, control frames are handled while waiting.
+// Returns *WsCloseError when the connection is closed by either side.
+func (ws *WsConn) ReadMessage() (messageType int, data []byte, err error) {
+	if ws.readErr != nil {
+		return 0, nil, ws.readErr
+	}
+
+	compressed := false
+
+	for {
+		frame, err := ws.readFrame(ws.readLimit - int64(len(data)))
+		if err != nil {
+			if ws.readErr == nil {
+				ws.readErr = err
+			}
+			return 0, nil, err
+		}
+
+		if frame.rsv1 && (frame.opcode != WsText && frame.opcode != WsBinary || !ws.compress) {
+			return 0, nil, ws.fail(WsCloseProtocolError, "unexpected compression")
+		}
+
+		switch frame.opcode {
+		case WsPing:
+			handler := ws.pingHandler
+			if handler == nil {
+				handler = ws.WritePong
+			}
+			if err = handler(frame.payload); err != nil && err != ErrWsClosed {
+				return 0, nil, err
+			}
+			continue
+		case WsPong:
+			if ws.pongHandler != nil {
+				if err = ws.pongHandler(frame.payload); err != nil {
+					return 0, nil, err
+				}
+			}
+			continue
+		case WsClose:
+			return 0, nil, ws.readClose(frame.payload)
+		case WsText, WsBinary:
+			if messageType != 0 {
+				return 0, nil, ws.fail(WsCloseProtocolError, "continuation expected")
+			}
+			messageType, compressed = frame.opcode, frame.rsv1
+		case wsContinuation:
+			if messageType == 0 {
+				return 0, nil, ws.fail(WsCloseProtocolError, "unexpected continuation")
+			}
+		default:
+			return 0, nil, ws.fail(WsCloseProtocolError, "reserved opcode")
+		}
+
+		data = append(data, frame.payload...)
+
+		if !frame.fin {
+			continue
+		}
+
+		if compressed {
+			data, err = wsInflate(data, ws.readLimit)
+			switch err {
+			case nil:
+			case ErrWsReadLimit:
+				return 0, nil, ws.fail(WsCloseTooBig, "")
+			default:
+				return 0, nil, ws.fail(WsCloseInvalidPayload, "")
+			}
+		}
+
+		if messageType == WsText && !utf8.Valid(data) {
+			return 0, nil, ws.fail(WsCloseInvalidPayload, "")
+		}
+
+		return messageType, data, nil
+	}
+}
+
+// Final empty stored block, lets flate reader end cleanly after the sync flush marker.
+var wsDeflateTail = []byte{0x00, 0x00, 0xff, 0xff, 0x01, 0x00, 0x00, 0xff, 0xff}
+
+func wsInflate(data []byte, limit int64) ([]byte, error) {
+	r := flate.NewReader(io.MultiReader(bytes.NewReader(data), bytes.NewReader(wsDeflateTail)))
+	defer r.Close()
+	b, err := ioutil.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(b)) > limit {
+		return nil, ErrWsReadLimit
+	}
+	return b, nil
+}
+
+func wsDeflate(data []byte) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w, err := flate.NewWriter(buf, flate.BestSpeed)
+	if err != nil {
+		return nil, err
+	}
+	w.Write(data)
+	if err = w.Flush(); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), wsDeflateTail[:4]), nil
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"sync"
+	"time"
+)
+
+// Broadcast messages to groups of WebSocket connections.
+type WsHub struct {
+	sync.RWMutex
+	groups map[string]map[*WsConn]bool
+	// Write timeout per connection, slower connections are dropped. Default 10 seconds.
+	WriteTimeout time.Duration
+}
+
+// Construct New WebSocket Hub
+func NewWsHub() *WsHub {
+	return &WsHub{groups: map[string]map[*WsConn]bool{}, WriteTimeout: 10 * time.Second}
+}
+
+// Add connection to group
+func (h *WsHub) Join(group string, conn *WsConn) {
+	h.Lock()
+	defer h.Unlock()
+	if h.groups[group] == nil {
+		h.groups[group] = map[*WsConn]bool{}
+	}
+	h.groups[group][conn] = true
+}
+
+// Remove connection from group
+func (h *WsHub) Leave(group string, conn *WsConn) {
+	h.Lock()
+	defer h.Unlock()
+	h.leave(group, conn)
+}
+
+func (h *WsHub) leave(group string, conn *WsConn) {
+	delete(h.groups[group], conn)
+	if len(h.groups[group]) == 0 {
+		delete(h.groups, group)
+	}
+}
+
+// Remove connection from every group, call when the connection is closed.
+func (h *WsHub) LeaveAll(conn *WsConn) {
+	h.Lock()
+	defer h.Unlock()
+	for group := range h.groups {
+		h.leave(group, conn)
+	}
+}
+
+// Number of connections in group
+func (h *WsHub) Count(group string) int {
+	h.RLock()
+	defer h.RUnlock()
+	return len(h.groups[group])
+}
+
+// Groups the connection belongs to
+func (h *WsHub) Groups(conn *WsConn) []string {
+	h.RLock()
+	defer h.RUnlock()
+	groups := []string{}
+	for group, conns := range h.groups {
+		if conns[conn] {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// Send Message to every connection in group, failed connections are closed and removed.
+// Returns number of successful sends.
+func (h *WsHub) Broadcast(group string, messageType int, data []byte) int {
+	h.RLock()
+	conns := make([]*WsConn, 0, len(h.groups[group]))
+	for conn := range h.groups[group] {
+		conns = append(conns, conn)
+	}
+	h.RUnlock()
+
+	sent := 0
+	for _, conn := range conns {
+		if h.WriteTimeout > 0 {
+			conn.SetWriteDeadline(time.Now().Add(h.WriteTimeout))
+		}
+		if err := conn.WriteMessage(messageType, data); err != nil {
+			conn.Close()
+			h.LeaveAll(conn)
+			continue
+		}
+		sent++
+	}
+	return sent
+}
+
+// Broadcast Text Message
+func (h *WsHub) BroadcastText(group, text string) int {
+	return h.Broadcast(group, WsText, []byte(text))
+}
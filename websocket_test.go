@@ -0,0 +1,268 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type wsTestClient struct {
+	conn net.Conn
+	br   *bufio.Reader
+	res  *http.Response
+}
+
+func wsTestDial(t *testing.T, ts *httptest.Server, header http.Header) *wsTestClient {
+	conn, err := net.Dial("tcp", strings.TrimPrefix(ts.URL, "http://"))
+	Check(err)
+
+	req, _ := http.NewRequest("GET", ts.URL+"/", nil)
+	req.Header.Set("Connection", "keep-alive, Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Write(conn)
+
+	br := bufio.NewReader(conn)
+	res, err := http.ReadResponse(br, req)
+	Check(err)
+
+	return &wsTestClient{conn, br, res}
+}
+
+func (cl *wsTestClient) write(fin, rsv1 bool, opcode int, payload []byte) {
+	b0 := byte(opcode)
+	if fin {
+		b0 |= 0x80
+	}
+	if rsv1 {
+		b0 |= 0x40
+	}
+	buf := []byte{b0}
+	switch {
+	case len(payload) <= 125:
+		buf = append(buf, 0x80|byte(len(payload)))
+	default:
+		buf = append(buf, 0x80|126, 0, 0)
+		binary.BigEndian.PutUint16(buf[2:], uint16(len(payload)))
+	}
+	mask := []byte{1, 2, 3, 4}
+	buf = append(buf, mask...)
+	for i, b := range payload {
+		buf = append(buf, b^mask[i%4])
+	}
+	cl.conn.Write(buf)
+}
+
+func (cl *wsTestClient) read() (opcode int, rsv1 bool, payload []byte) {
+	header := make([]byte, 2)
+	if _, err := io.ReadFull(cl.br, header); err != nil {
+		return -1, false, nil
+	}
+	length := int(header[1] & 0x7f)
+	if length == 126 {
+		io.ReadFull(cl.br, header)
+		length = int(binary.BigEndian.Uint16(header))
+	}
+	payload = make([]byte, length)
+	io.ReadFull(cl.br, payload)
+	return int(header[0] & 0x0f), header[0]&0x40 != 0, payload
+}
+
+func TestWebSocket(t *testing.T) {
+	App := NewApp()
+
+	hub := NewWsHub()
+
+	upgrader := &WsUpgrader{Subprotocols: []string{"chat", "echo"}, Compression: true, ReadLimit: 1000}
+
+	App.DefaultRouter = RouteHandlerFunc(func(c *Context) {
+		conn, err := upgrader.Upgrade(c)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		hub.Join("room", conn)
+		defer hub.LeaveAll(conn)
+
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if string(data) == "broadcast" {
+				hub.BroadcastText("room", "all")
+				continue
+			}
+			conn.WriteMessage(messageType, data)
+		}
+	})
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	cl := wsTestDial(t, ts, http.Header{"Sec-Websocket-Protocol": {"echo, chat"}})
+	defer cl.conn.Close()
+
+	if cl.res.StatusCode != 101 || cl.res.Header.Get("Sec-Websocket-Accept") != "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=" ||
+		cl.res.Header.Get("Sec-Websocket-Protocol") != "chat" || cl.res.Header.Get("Sec-Websocket-Extensions") != "" {
+		t.Fail()
+	}
+
+	// Fragmented text with ping in between
+	cl.write(false, false, WsText, []byte("hel"))
+	cl.write(true, false, WsPing, []byte("p"))
+	cl.write(true, false, wsContinuation, []byte("lo"))
+
+	if opcode, _, payload := cl.read(); opcode != WsPong || string(payload) != "p" {
+		t.Fail()
+	}
+
+	if opcode, _, payload := cl.read(); opcode != WsText || string(payload) != "hello" {
+		t.Fail()
+	}
+
+	cl.write(true, false, WsText, []byte("broadcast"))
+
+	if opcode, _, payload := cl.read(); opcode != WsText || string(payload) != "all" {
+		t.Fail()
+	}
+
+	// Read limit
+	cl.write(true, false, WsBinary, make([]byte, 1001))
+
+	if opcode, _, payload := cl.read(); opcode != WsClose || binary.BigEndian.Uint16(payload) != WsCloseTooBig {
+		t.Fail()
+	}
+
+	// Compression
+	cl = wsTestDial(t, ts, http.Header{"Sec-Websocket-Extensions": {"permessage-deflate; client_max_window_bits"}})
+	defer cl.conn.Close()
+
+	if !strings.HasPrefix(cl.res.Header.Get("Sec-Websocket-Extensions"), "permessage-deflate") {
+		t.Fail()
+	}
+
+	buf := &bytes.Buffer{}
+	fw, _ := flate.NewWriter(buf, flate.BestCompression)
+	fw.Write([]byte(strings.Repeat("compress ", 50)))
+	fw.Flush()
+	cl.write(true, true, WsText, bytes.TrimSuffix(buf.Bytes(), []byte{0, 0, 0xff, 0xff}))
+
+	opcode, rsv1, payload := cl.read()
+	fr := flate.NewReader(io.MultiReader(bytes.NewReader(payload), bytes.NewReader(wsDeflateTail)))
+	b, _ := ioutil.ReadAll(fr)
+
+	if opcode != WsText || !rsv1 || string(b) != strings.Repeat("compress ", 50) {
+		t.Fail()
+	}
+
+	// Close handshake
+	cl.write(true, false, WsClose, []byte{0x03, 0xe8})
+
+	if opcode, _, payload := cl.read(); opcode != WsClose || binary.BigEndian.Uint16(payload) != WsCloseNormal {
+		t.Fail()
+	}
+
+	// Origin check
+	cl = wsTestDial(t, ts, http.Header{"Origin": {"http://evil.example"}})
+	defer cl.conn.Close()
+
+	if cl.res.StatusCode != 403 {
+		t.Fail()
+	}
+
+	// Unmasked frame
+	cl = wsTestDial(t, ts, nil)
+	defer cl.conn.Close()
+
+	cl.conn.Write([]byte{0x81, 0x01, 'a'})
+
+	if opcode, _, payload := cl.read(); opcode != WsClose || binary.BigEndian.Uint16(payload) != WsCloseProtocolError {
+		t.Fail()
+	}
+}
+
+type MethodWsDummy struct {
+	Method
+}
+
+func (me *MethodWsDummy) Ws() {
+	conn, err := me.C.WsUpgrade()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	messageType, data, err := conn.ReadMessage()
+	if err != nil {
+		return
+	}
+	conn.WriteMessage(messageType, data)
+}
+
+func (me *MethodWsDummy) Get() {
+	me.C.Fmt().Print("get")
+}
+
+func (me *MethodWsDummy) Finish() {
+	me.C.Pub.Group.Set("finish", "FINISH")
+}
+
+func TestWebSocketMethod(t *testing.T) {
+	App := NewApp()
+
+	App.PanicHandler = panicFunc(func(c *Context, r interface{}, stack []byte) {
+		t.Error(r)
+	})
+
+	finish := make(chan string, 1)
+	App.Use(func(c *Context, next func()) {
+		c.IO().Gzip()
+		next()
+		finish <- c.Pub.Group.Get("finish")
+	})
+
+	App.DefaultRouter = &MethodWsDummy{}
+
+	ts := httptest.NewServer(App)
+	defer ts.Close()
+
+	cl := wsTestDial(t, ts, http.Header{"Accept-Encoding": {"gzip"}})
+	defer cl.conn.Close()
+
+	if cl.res.StatusCode != 101 || cl.res.Header.Get("Content-Encoding") != "" {
+		t.Fail()
+	}
+
+	cl.write(true, false, WsText, []byte("hello"))
+
+	if opcode, _, payload := cl.read(); opcode != WsText || string(payload) != "hello" {
+		t.Fail()
+	}
+
+	// Upgrade terminates the request, Get is skipped and Finish still runs
+	if <-finish != "FINISH" {
+		t.Fail()
+	}
+
+	res, _ := http.Get(ts.URL)
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+
+	if string(body) != "get" || <-finish != "FINISH" {
+		t.Fail()
+	}
+}